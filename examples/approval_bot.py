@@ -0,0 +1,65 @@
+"""Approve small plans automatically and ask for smaller plans otherwise.
+
+Usage:
+    export JULES_API_KEY="your-api-key-here"
+    python examples/approval_bot.py SESSION_ID [MAX_STEPS]
+"""
+
+import os
+import sys
+from typing import Optional
+
+from jules_agent_sdk import JulesClient, PlanSupersededError
+from jules_agent_sdk.models import Plan, SessionState
+
+
+def latest_plan(client: JulesClient, session_id: str) -> Optional[Plan]:
+    """Return the most recently generated plan of a session."""
+    plans = [a.plan for a in client.activities.list_all(session_id) if a.plan]
+    return plans[-1] if plans else None
+
+
+def review(client: JulesClient, session_id: str, max_steps: int = 5) -> str:
+    """Review the plan awaiting approval, if any.
+
+    Args:
+        client: Client to use
+        session_id: Session to review
+        max_steps: Largest plan approved without a human
+
+    Returns:
+        "approved", "rejected", "superseded" or "idle"
+    """
+    if client.sessions.get(session_id).state != SessionState.AWAITING_PLAN_APPROVAL:
+        return "idle"
+    plan = latest_plan(client, session_id)
+    if plan is None:
+        return "idle"
+
+    if len(plan.steps) > max_steps:
+        client.sessions.send_message(
+            session_id, f"Please split this into a plan of at most {max_steps} steps."
+        )
+        print(f"Asked for a smaller plan than {plan}")
+        return "rejected"
+
+    try:
+        client.sessions.approve_plan(session_id, plan_id=plan.id)
+    except PlanSupersededError as e:
+        print(f"{plan} was replaced by {e.plan}; reviewing again on the next run")
+        return "superseded"
+    print(f"Approved {plan}")
+    return "approved"
+
+
+def main() -> None:
+    """Run the example from the command line."""
+    if len(sys.argv) not in (2, 3):
+        sys.exit(__doc__)
+    max_steps = int(sys.argv[2]) if len(sys.argv) == 3 else 5
+    with JulesClient(api_key=os.environ["JULES_API_KEY"]) as client:
+        print(review(client, sys.argv[1], max_steps))
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,52 @@
+"""Create a session and wait for it to finish.
+
+Usage:
+    export JULES_API_KEY="your-api-key-here"
+    python examples/create_and_wait.py octo/app "Add type hints to utils.py"
+"""
+
+import os
+import sys
+
+from jules_agent_sdk import JulesClient
+from jules_agent_sdk.models import Session, SessionState
+
+
+def run(client: JulesClient, source: str, prompt: str, timeout: int = 1800) -> Session:
+    """Create a session on source and wait for it to complete.
+
+    Args:
+        client: Client to use
+        source: Source name, ID or GitHub "owner/repo"
+        prompt: Task for the agent
+        timeout: Seconds to wait for completion
+
+    Returns:
+        The final session
+    """
+    session = client.sessions.create(prompt=prompt, source=source)
+    print(f"Created {session.name}: {session.url}")
+
+    final = client.sessions.wait_for_completion(
+        session.name,
+        timeout=timeout,
+        not_found_grace=30,
+        on_state_change=lambda s, old: print(f"  {old} -> {s.state}"),
+    )
+
+    if final.state == SessionState.COMPLETED and final.pull_request:
+        print(f"Pull request: {final.pull_request.url}")
+    return final
+
+
+def main() -> None:
+    """Run the example from the command line."""
+    if len(sys.argv) != 3:
+        sys.exit(__doc__)
+    with JulesClient(api_key=os.environ["JULES_API_KEY"]) as client:
+        final = run(client, sys.argv[1], sys.argv[2])
+        print(f"Finished in state {final.state}")
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,60 @@
+"""Run one task across several repositories in parallel.
+
+Usage:
+    export JULES_API_KEY="your-api-key-here"
+    python examples/fan_out.py "Upgrade to Python 3.12" octo/app octo/api octo/web
+"""
+
+import os
+import sys
+from concurrent.futures import ThreadPoolExecutor
+from typing import Dict, List
+
+from jules_agent_sdk import JulesAPIError, JulesClient
+from jules_agent_sdk.models import Session
+
+
+def run(
+    client: JulesClient, prompt: str, sources: List[str], timeout: int = 3600
+) -> Dict[str, Session]:
+    """Create one session per source and wait for all of them.
+
+    Args:
+        client: Client to use; it is safe to share between threads
+        prompt: Task for the agent
+        sources: Source names, IDs or GitHub "owner/repo" shorthands
+        timeout: Seconds to wait for each session
+
+    Returns:
+        Final session per source, for the sessions that could be started
+    """
+
+    def start_and_wait(source: str) -> Session:
+        session = client.sessions.create(prompt=prompt, source=source)
+        print(f"{source}: started {session.name}")
+        return client.sessions.wait_for_completion(
+            session.name, timeout=timeout, not_found_grace=30
+        )
+
+    results: Dict[str, Session] = {}
+    with ThreadPoolExecutor(max_workers=min(len(sources), 8) or 1) as pool:
+        futures = {source: pool.submit(start_and_wait, source) for source in sources}
+        for source, future in futures.items():
+            try:
+                results[source] = future.result()
+                print(f"{source}: {results[source].state}")
+            except JulesAPIError as e:
+                print(f"{source}: {e}")
+    return results
+
+
+def main() -> None:
+    """Run the example from the command line."""
+    if len(sys.argv) < 3:
+        sys.exit(__doc__)
+    with JulesClient(api_key=os.environ["JULES_API_KEY"], max_creates_per_minute=10) as client:
+        run(client, sys.argv[1], sys.argv[2:])
+
+
+if __name__ == "__main__":
+    main()
@@ -2,21 +2,91 @@
 
 from jules_agent_sdk.client import JulesClient
 from jules_agent_sdk.async_client import AsyncJulesClient
+from jules_agent_sdk.cancellation import AsyncCancelEvent, CancelEvent, CancelReason
+from jules_agent_sdk.circuit import CircuitBreaker
+from jules_agent_sdk.context import use_api_key, use_correlation_id, use_headers
+from jules_agent_sdk.decoding import DecodeError, DecodeOptions
+from jules_agent_sdk.filters import SourceFilter
+from jules_agent_sdk.extensions import register_activity_kind, unregister_activity_kind
+from jules_agent_sdk.logsampling import SampledRequestFilter
+from jules_agent_sdk.messaging import AsyncMessageQueue, MessageQueue
+from jules_agent_sdk.resources import parse_resource_name, parse_session_url
+from jules_agent_sdk.retry import (
+    DecorrelatedJitter,
+    ExponentialBackoff,
+    FixedDelay,
+    NoRetry,
+    RetryPolicy,
+)
+from jules_agent_sdk.sessions import WaitStats
 from jules_agent_sdk.exceptions import (
+    BranchNotFoundError,
+    CircuitOpenError,
     JulesAPIError,
     JulesAuthenticationError,
     JulesNotFoundError,
     JulesValidationError,
     JulesRateLimitError,
+    JulesTimeoutError,
+    InvalidResourceNameError,
+    PaginationLoopError,
+    PlanSupersededError,
+    PollLimitExceededError,
+    PromptTooLargeError,
+    ReadOnlyModeError,
+    SessionStalledError,
+    SourceNotFoundError,
+    UnexpectedContentTypeError,
+    WaitCancelledError,
+    is_retryable,
+    retry_delay_hint,
 )
 
 __version__ = "0.1.0"
 __all__ = [
     "JulesClient",
     "AsyncJulesClient",
+    "CancelEvent",
+    "AsyncCancelEvent",
+    "CancelReason",
+    "CircuitBreaker",
+    "use_api_key",
+    "use_headers",
+    "use_correlation_id",
+    "parse_session_url",
+    "parse_resource_name",
+    "DecodeOptions",
+    "DecodeError",
+    "SourceFilter",
+    "register_activity_kind",
+    "unregister_activity_kind",
+    "SampledRequestFilter",
+    "MessageQueue",
+    "AsyncMessageQueue",
+    "WaitStats",
+    "RetryPolicy",
+    "ExponentialBackoff",
+    "DecorrelatedJitter",
+    "FixedDelay",
+    "NoRetry",
     "JulesAPIError",
+    "BranchNotFoundError",
+    "CircuitOpenError",
     "JulesAuthenticationError",
     "JulesNotFoundError",
     "JulesValidationError",
     "JulesRateLimitError",
+    "JulesTimeoutError",
+    "InvalidResourceNameError",
+    "PaginationLoopError",
+    "PlanSupersededError",
+    "PollLimitExceededError",
+    "PromptTooLargeError",
+    "ReadOnlyModeError",
+    "SessionStalledError",
+    "SourceNotFoundError",
+    "UnexpectedContentTypeError",
+    "WaitCancelledError",
+    "is_retryable",
+    "retry_delay_hint",
 ]
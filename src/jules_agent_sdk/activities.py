@@ -3,18 +3,27 @@
 from typing import Optional, List, Dict, Any
 from jules_agent_sdk.models import Activity
 from jules_agent_sdk.base import BaseClient
+from jules_agent_sdk.decoding import decode
+from jules_agent_sdk.pagination import DEFAULT_MAX_PAGES, PageTracker, list_params
+from jules_agent_sdk.resources import activity_path, session_path
 
 
 class ActivitiesAPI:
     """API client for managing session activities."""
 
-    def __init__(self, client: BaseClient) -> None:
+    def __init__(
+        self, client: BaseClient, default_page_size: Optional[int] = None, strict_ids: bool = False
+    ) -> None:
         """Initialize the Activities API.
 
         Args:
             client: Base HTTP client instance
+            default_page_size: Page size used when a call does not specify one
+            strict_ids: Reject ambiguous IDs instead of normalizing them
         """
         self.client = client
+        self.default_page_size = default_page_size
+        self.strict_ids = strict_ids
 
     def get(self, session_id: str, activity_id: str) -> Activity:
         """Get a single activity by ID.
@@ -30,12 +39,9 @@ class ActivitiesAPI:
             >>> activity = client.activities.get("session123", "activity456")
             >>> print(activity.description)
         """
-        if not session_id.startswith("sessions/"):
-            session_id = f"sessions/{session_id}"
-
-        path = f"{session_id}/activities/{activity_id}"
+        path = activity_path(session_id, activity_id, self.strict_ids)
         response = self.client.get(path)
-        return Activity.from_dict(response)
+        return decode(Activity, response, self.client.decode_options)
 
     def list(
         self,
@@ -47,7 +53,8 @@ class ActivitiesAPI:
 
         Args:
             session_id: The session ID or full name
-            page_size: Maximum number of activities to return
+            page_size: Maximum number of activities to return (clamped to the API
+                maximum; defaults to the client's default_page_size)
             page_token: Token for pagination
 
         Returns:
@@ -64,48 +71,56 @@ class ActivitiesAPI:
             ...         page_token=result['nextPageToken']
             ...     )
         """
-        if not session_id.startswith("sessions/"):
-            session_id = f"sessions/{session_id}"
+        session_id = session_path(session_id, self.strict_ids)
 
-        params: Dict[str, Any] = {}
-        if page_size is not None:
-            params["pageSize"] = page_size
-        if page_token:
-            params["pageToken"] = page_token
+        params = list_params(page_size, page_token, self.default_page_size)
 
         path = f"{session_id}/activities"
         response = self.client.get(path, params=params)
 
         activities = []
         if response.get("activities"):
-            activities = [Activity.from_dict(a) for a in response["activities"]]
+            options = self.client.decode_options
+            activities = [decode(Activity, a, options) for a in response["activities"]]
 
         return {
             "activities": activities,
             "nextPageToken": response.get("nextPageToken"),
         }
 
-    def list_all(self, session_id: str) -> List[Activity]:
+    def list_all(
+        self,
+        session_id: str,
+        page_size: Optional[int] = None,
+        max_pages: Optional[int] = DEFAULT_MAX_PAGES,
+    ) -> List[Activity]:
         """List all activities for a session (handles pagination automatically).
 
         Args:
             session_id: The session ID or full name
+            page_size: Activities fetched per request; larger pages mean fewer
+                round trips for long sessions
+            max_pages: Safety limit on pages fetched (default: 1000, None for no limit)
 
         Returns:
             List of all Activity objects
 
+        Raises:
+            PaginationLoopError: If a page token repeats or max_pages is exceeded
+
         Example:
             >>> all_activities = client.activities.list_all("session123")
             >>> print(f"Total activities: {len(all_activities)}")
         """
         all_activities: List[Activity] = []
         page_token: Optional[str] = None
+        tracker = PageTracker(f"activities of {session_id}", max_pages)
 
         while True:
-            result = self.list(session_id, page_token=page_token)
+            result = self.list(session_id, page_size=page_size, page_token=page_token)
             all_activities.extend(result["activities"])
 
-            page_token = result.get("nextPageToken")
+            page_token = tracker.advance(result.get("nextPageToken"))
             if not page_token:
                 break
 
@@ -0,0 +1,118 @@
+"""Indexed view of the artifacts produced across a session's activities."""
+
+import threading
+from dataclasses import dataclass
+from typing import Callable, Dict, Iterator, List, Optional
+
+from jules_agent_sdk.models import Activity, Artifact, BashOutput, ChangeSet, Media
+
+# Artifact types, named after their API keys
+CHANGE_SET = "changeSet"
+MEDIA = "media"
+BASH_OUTPUT = "bashOutput"
+ARTIFACT_TYPES = (CHANGE_SET, MEDIA, BASH_OUTPUT)
+
+
+@dataclass(frozen=True)
+class ArtifactRef:
+    """An artifact together with the activity that produced it."""
+
+    activity: Activity
+    artifact: Artifact
+
+    @property
+    def type(self) -> str:
+        """The artifact type, one of ARTIFACT_TYPES, or "" if it is empty."""
+        if self.artifact.change_set is not None:
+            return CHANGE_SET
+        if self.artifact.media is not None:
+            return MEDIA
+        if self.artifact.bash_output is not None:
+            return BASH_OUTPUT
+        return ""
+
+
+class ArtifactIndex:
+    """All artifacts of a session, in activity order and grouped by type.
+
+    Activities are fetched on first access, so creating an index is free and
+    the artifacts are only listed once however many views are read.
+
+    Example:
+        >>> artifacts = client.sessions.artifacts("abc123")
+        >>> for output in artifacts.bash_outputs:
+        ...     print(output.command, output.exit_code)
+        >>> patch = artifacts.latest_change_set
+    """
+
+    def __init__(self, loader: Callable[[], List[Activity]]) -> None:
+        """Initialize the index.
+
+        Args:
+            loader: Returns the session's activities; called once, on first access
+        """
+        self._loader = loader
+        self._refs: Optional[List[ArtifactRef]] = None
+        self._lock = threading.Lock()
+
+    @classmethod
+    def from_activities(cls, activities: List[Activity]) -> "ArtifactIndex":
+        """Build an index over activities that were already fetched."""
+        return cls(lambda: activities)
+
+    @property
+    def loaded(self) -> bool:
+        """Whether the activities have been fetched."""
+        return self._refs is not None
+
+    def refs(self) -> List[ArtifactRef]:
+        """Return every artifact with its activity, in activity order."""
+        with self._lock:
+            if self._refs is None:
+                self._refs = [
+                    ArtifactRef(activity, artifact)
+                    for activity in self._loader()
+                    for artifact in activity.artifacts
+                ]
+            return self._refs
+
+    def by_type(self, artifact_type: str) -> List[ArtifactRef]:
+        """Return the artifacts of one type, e.g. MEDIA, in activity order."""
+        return [ref for ref in self.refs() if ref.type == artifact_type]
+
+    def counts(self) -> Dict[str, int]:
+        """Return the number of artifacts of each type."""
+        counts = dict.fromkeys(ARTIFACT_TYPES, 0)
+        for ref in self.refs():
+            if ref.type:
+                counts[ref.type] += 1
+        return counts
+
+    @property
+    def change_sets(self) -> List[ChangeSet]:
+        """All change sets, in activity order."""
+        return [ref.artifact.change_set for ref in self.by_type(CHANGE_SET)]  # type: ignore[misc]
+
+    @property
+    def media(self) -> List[Media]:
+        """All media artifacts, in activity order."""
+        return [ref.artifact.media for ref in self.by_type(MEDIA)]  # type: ignore[misc]
+
+    @property
+    def bash_outputs(self) -> List[BashOutput]:
+        """All bash outputs, in activity order."""
+        return [ref.artifact.bash_output for ref in self.by_type(BASH_OUTPUT)]  # type: ignore[misc]
+
+    @property
+    def latest_change_set(self) -> Optional[ChangeSet]:
+        """The most recent change set, or None if the session has none."""
+        change_sets = self.change_sets
+        return change_sets[-1] if change_sets else None
+
+    def __iter__(self) -> Iterator[ArtifactRef]:
+        """Iterate over every artifact with its activity."""
+        return iter(self.refs())
+
+    def __len__(self) -> int:
+        """Return the total number of artifacts."""
+        return len(self.refs())
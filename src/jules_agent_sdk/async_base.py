@@ -1,6 +1,10 @@
 """Async base HTTP client for Jules API."""
 
-from typing import Optional, Dict, Any
+import asyncio
+import json as jsonlib
+from decimal import Decimal
+from typing import Optional, Dict, Any, Callable
+from urllib.parse import urlsplit
 import aiohttp
 from jules_agent_sdk.exceptions import (
     JulesAPIError,
@@ -9,6 +13,22 @@ from jules_agent_sdk.exceptions import (
     JulesValidationError,
     JulesRateLimitError,
     JulesServerError,
+    ReadOnlyModeError,
+    UnexpectedContentTypeError,
+)
+from jules_agent_sdk.base import parse_retry_after, response_request_id
+from jules_agent_sdk.callbacks import invoke_async_callback
+from jules_agent_sdk.decoding import DecodeOptions
+from jules_agent_sdk.deprecation import DeprecationTracker
+from jules_agent_sdk.resources import route
+from jules_agent_sdk.retry import DEFAULT_MAX_RETRY_AFTER, ExponentialBackoff
+from jules_agent_sdk.sourcemap import SourceNameCache
+from jules_agent_sdk.context import (
+    DEFAULT_CORRELATION_ID_HEADER,
+    current_api_key,
+    current_correlation_id,
+    current_headers,
+    note_request,
 )
 
 
@@ -17,25 +37,119 @@ class AsyncBaseClient:
 
     BASE_URL = "https://jules.googleapis.com/v1alpha"
 
-    def __init__(self, api_key: str, base_url: Optional[str] = None) -> None:
+    def __init__(
+        self,
+        api_key: str,
+        base_url: Optional[str] = None,
+        connector_kwargs: Optional[Dict[str, Any]] = None,
+        max_connections: int = 100,
+        max_connections_per_host: int = 0,
+        extra_headers: Optional[Dict[str, str]] = None,
+        correlation_id_extractor: Optional[Callable[[], Optional[str]]] = current_correlation_id,
+        correlation_id_header: str = DEFAULT_CORRELATION_ID_HEADER,
+        read_only: bool = False,
+        decode_options: Optional[DecodeOptions] = None,
+        raise_callback_errors: bool = False,
+        max_retry_after: float = DEFAULT_MAX_RETRY_AFTER,
+    ) -> None:
         """Initialize the async base client.
 
         Args:
             api_key: Jules API key for authentication
             base_url: Optional custom base URL (defaults to official API endpoint)
+            connector_kwargs: Options for aiohttp.TCPConnector, such as resolver,
+                family, local_addr or keepalive_timeout
+            max_connections: Maximum simultaneous connections (0 for no limit)
+            max_connections_per_host: Maximum simultaneous connections per host
+                (0 for no limit)
+            extra_headers: Headers sent with every request
+            correlation_id_extractor: Called once per request to get a correlation
+                ID (None disables)
+            correlation_id_header: Header carrying the correlation ID
+            read_only: Reject every non-GET request with ReadOnlyModeError
+            decode_options: Strictness of response decoding (default: lenient)
+            raise_callback_errors: Propagate exceptions from notification callbacks
+                such as on_state_change instead of logging them
+            max_retry_after: Longest Retry-After delay in seconds that a 429 is
+                retried after; longer delays raise JulesRateLimitError at once
+                (0 disables retrying rate limited requests)
         """
         self.api_key = api_key
+        self.decode_options = decode_options or DecodeOptions()
+        # Shared by every API object of the client, see SourcesAPI.resolve_name
+        self.source_names = SourceNameCache()
+        self.read_only = read_only
+        self.deprecations = DeprecationTracker()
+        self.correlation_id_extractor = correlation_id_extractor
+        self.correlation_id_header = correlation_id_header
+        self.extra_headers = dict(extra_headers or {})
         self.base_url = base_url or self.BASE_URL
+        self.connector_kwargs = {
+            "limit": max_connections,
+            "limit_per_host": max_connections_per_host,
+            **(connector_kwargs or {}),
+        }
+        self.raise_callback_errors = raise_callback_errors
+        # Only rate limited requests are retried by the async client
+        self.rate_limit_policy = ExponentialBackoff(max_retry_after=max_retry_after)
+        self.callback_error_count = 0
         self._session: Optional[aiohttp.ClientSession] = None
 
+    async def _notify(self, callback: Optional[Callable[..., Any]], *args: Any) -> None:
+        """Invoke a notification callback, counting failures instead of raising.
+
+        Args:
+            callback: Callback to invoke, sync or async; None is a no-op
+            *args: Arguments passed to the callback
+        """
+        if not await invoke_async_callback(
+            callback, *args, propagate=self.raise_callback_errors
+        ):
+            self.callback_error_count += 1
+
     async def _get_session(self) -> aiohttp.ClientSession:
         """Get or create the aiohttp session."""
         if self._session is None or self._session.closed:
             self._session = aiohttp.ClientSession(
-                headers={"X-Goog-Api-Key": self.api_key}
+                headers={"X-Goog-Api-Key": self.api_key, **self.extra_headers},
+                connector=aiohttp.TCPConnector(**self.connector_kwargs),
             )
         return self._session
 
+    async def _parse_json(self, response: aiohttp.ClientResponse) -> Dict[str, Any]:
+        """Decode a response body as JSON.
+
+        Args:
+            response: HTTP response object
+
+        Returns:
+            Decoded response body
+
+        Raises:
+            UnexpectedContentTypeError: If the body is not valid JSON
+        """
+        text = await response.text()
+        try:
+            if self.decode_options.use_decimal:
+                return jsonlib.loads(text, parse_float=Decimal)
+            return jsonlib.loads(text)
+        except ValueError as e:
+            raise UnexpectedContentTypeError.for_status(
+                response.status, response.headers.get("Content-Type", ""), text
+            ) from e
+
+    def _request_headers(self) -> Dict[str, str]:
+        """Build headers that vary per call on top of the session defaults."""
+        headers = current_headers()
+        if self.correlation_id_extractor is not None:
+            correlation_id = self.correlation_id_extractor()
+            if correlation_id:
+                headers[self.correlation_id_header] = correlation_id
+        api_key = current_api_key()
+        if api_key:
+            headers["X-Goog-Api-Key"] = api_key
+        return headers
+
     async def _handle_error(self, response: aiohttp.ClientResponse) -> None:
         """Handle HTTP error responses.
 
@@ -48,12 +162,17 @@ class AsyncBaseClient:
             JulesValidationError: For 400 errors
             JulesRateLimitError: For 429 errors
             JulesServerError: For 5xx errors
+            UnexpectedContentTypeError: For error bodies that are not JSON, also an
+                instance of the status code's exception class
             JulesAPIError: For other errors
         """
         try:
-            error_data = await response.json()
-        except Exception:
-            error_data = {"error": await response.text()}
+            error_data = await self._parse_json(response)
+        except UnexpectedContentTypeError:
+            if response.status != 429:
+                raise
+            # Proxies answer rate limits in plain text; keep the type and Retry-After
+            error_data = {"error": {"message": "Rate limit exceeded"}}
 
         error_msg = error_data.get("error", {}).get("message", str(error_data))
 
@@ -64,6 +183,9 @@ class AsyncBaseClient:
         elif response.status == 400:
             raise JulesValidationError(error_msg, response.status, error_data)
         elif response.status == 429:
+            retry_after = parse_retry_after(response.headers.get("Retry-After"))
+            if retry_after is not None:
+                error_data["retry_after_seconds"] = retry_after
             raise JulesRateLimitError(error_msg, response.status, error_data)
         elif response.status >= 500:
             raise JulesServerError(error_msg, response.status, error_data)
@@ -88,22 +210,68 @@ class AsyncBaseClient:
         Returns:
             API response as dictionary
 
+        A 429 is retried after its Retry-After delay, up to max_retry_after;
+        other errors are raised at once.
+
         Raises:
+            ReadOnlyModeError: If the client is read-only and method is not GET
+            JulesRateLimitError: If rate limited beyond the retry limits
             JulesAPIError: On API error
         """
+        if self.read_only and method != "GET":
+            error = ReadOnlyModeError(f"{method} {path} is not allowed on a read-only client")
+            error.operation = f"{method} {path}"
+            error.resource = path.split(":", 1)[0]
+            raise error
+
+        note_request()
         session = await self._get_session()
         url = f"{self.base_url}/{path.lstrip('/')}"
+        headers = self._request_headers()
+        policy = self.rate_limit_policy
+        attempt, delay = 0, 0.0
+
+        while True:
+            attempt += 1
+            try:
+                async with session.request(
+                    method=method,
+                    url=url,
+                    params=params,
+                    json=json,
+                    headers=headers,
+                ) as response:
+                    endpoint = f"{method} {route(urlsplit(url).path)}"
+                    self.deprecations.observe(endpoint, response.headers)
+                    if not response.ok:
+                        try:
+                            await self._handle_error(response)
+                        except JulesAPIError as e:
+                            e.request_id = response_request_id(response.headers)
+                            raise
 
-        async with session.request(
-            method=method, url=url, params=params, json=json
-        ) as response:
-            if not response.ok:
-                await self._handle_error(response)
+                    if response.status == 204 or not response.content_length:
+                        return {}
 
-            if response.status == 204 or not response.content_length:
-                return {}
+                    return await self._parse_json(response)
+            except JulesRateLimitError as e:
+                if not policy.should_retry(method, e, attempt):
+                    self._annotate(e, method, path, headers, attempt)
+                    raise
+                delay = policy.next_delay(e, attempt, delay)
+                await asyncio.sleep(delay)
+            except JulesAPIError as e:
+                self._annotate(e, method, path, headers, attempt)
+                raise
 
-            return await response.json()
+    def _annotate(
+        self, error: JulesAPIError, method: str, path: str, headers: Dict[str, str], attempts: int
+    ) -> None:
+        """Attach the request context to an error before it is raised."""
+        error.operation = f"{method} {path}"
+        error.resource = path.split(":", 1)[0]
+        error.attempts = attempts
+        error.correlation_id = headers.get(self.correlation_id_header)
 
     async def get(
         self, path: str, params: Optional[Dict[str, Any]] = None
@@ -1,18 +1,128 @@
 """Async Jules API client."""
 
-from typing import Optional, List, Dict, Any
+from typing import Optional, List, Dict, Any, AsyncIterator, Callable, Union, Awaitable, Tuple
 import asyncio
+import inspect
+import logging
+from datetime import datetime
 from jules_agent_sdk.async_base import AsyncBaseClient
-from jules_agent_sdk.models import Session, Activity, Source, SessionState
-from jules_agent_sdk.exceptions import JulesAPIError
+from jules_agent_sdk.artifacts import ArtifactIndex
+from jules_agent_sdk.filters import SourceFilterLike, split_filter
+from jules_agent_sdk.decoding import DecodeOptions, decode
+from jules_agent_sdk.deprecation import DeprecationNotice
+from jules_agent_sdk.context import (
+    DEFAULT_CORRELATION_ID_HEADER,
+    current_correlation_id,
+    use_headers,
+)
+from jules_agent_sdk.models import (
+    Activity,
+    CompletionDetails,
+    Plan,
+    PlanProgress,
+    Session,
+    SessionState,
+    Source,
+    parse_timestamp,
+)
+from jules_agent_sdk.exceptions import (
+    BranchNotFoundError,
+    JulesAPIError,
+    JulesNotFoundError,
+    JulesTimeoutError,
+    JulesValidationError,
+    PlanSupersededError,
+    PollLimitExceededError,
+    SessionStalledError,
+    SourceNotFoundError,
+    WaitCancelledError,
+)
+from jules_agent_sdk.messaging import CONFLICT_STATUSES
+from jules_agent_sdk.sessions import (
+    CONFLICT_BACKOFF,
+    DEFAULT_IDEMPOTENCY_KEY_HEADER,
+    DEFAULT_TRANSITION_TIMEOUT,
+    TRANSITION_POLL_INTERVAL,
+    CreateInterceptor,
+    WaitStats,
+    _idempotency_headers,
+    _track_wait,
+)
+from jules_agent_sdk.prompt import PromptProcessor, apply_processors, check_prompt
+from jules_agent_sdk.suggest import close_matches
+from jules_agent_sdk.throttle import CreateThrottle
+from jules_agent_sdk.pagination import DEFAULT_MAX_PAGES, PageTracker, list_params
+from jules_agent_sdk.retry import DEFAULT_MAX_RETRY_AFTER
+from jules_agent_sdk.resources import activity_path, session_path, source_path
+from jules_agent_sdk.sourcemap import is_repo_shorthand
+
+logger = logging.getLogger(__name__)
+
+# Async counterpart of FeedbackHandler; may return the reply or an awaitable of it.
+AsyncFeedbackHandler = Callable[[Session, str], Union[str, Awaitable[str]]]
+
+# Async counterpart of StateChangeHandler; may return an awaitable.
+AsyncStateChangeHandler = Callable[[Session, Optional[SessionState]], Optional[Awaitable[None]]]
+
+# Async counterpart of InactivityHandler; may return an awaitable.
+AsyncInactivityHandler = Callable[[Session, float], Optional[Awaitable[None]]]
+
+# Async counterpart of QueuedStallHandler; may return an awaitable.
+AsyncQueuedStallHandler = Callable[
+    [Session, float], Union[Optional[Session], Awaitable[Optional[Session]]]
+]
+
+
+async def _pause(seconds: float, cancel_event: Optional[asyncio.Event]) -> None:
+    """Sleep between polls, waking early if cancel_event is set."""
+    if cancel_event is None:
+        await asyncio.sleep(seconds)
+        return
+    try:
+        await asyncio.wait_for(cancel_event.wait(), seconds)
+    except asyncio.TimeoutError:
+        pass
 
 
 class AsyncSessionsAPI:
     """Async API client for managing Jules sessions."""
 
-    def __init__(self, client: AsyncBaseClient) -> None:
+    def __init__(
+        self,
+        client: AsyncBaseClient,
+        default_require_plan_approval: bool = False,
+        create_interceptors: Optional[List[CreateInterceptor]] = None,
+        max_prompt_tokens: Optional[int] = None,
+        default_page_size: Optional[int] = None,
+        strict_ids: bool = False,
+        max_creates_per_minute: Optional[int] = None,
+        prompt_processors: Optional[List[PromptProcessor]] = None,
+        pin_default_branch: bool = False,
+        idempotency_key_header: Optional[str] = DEFAULT_IDEMPOTENCY_KEY_HEADER,
+    ) -> None:
         """Initialize the async Sessions API."""
         self.client = client
+        self.default_require_plan_approval = default_require_plan_approval
+        self.create_interceptors: List[CreateInterceptor] = list(create_interceptors or [])
+        self.max_prompt_tokens = max_prompt_tokens
+        self.prompt_processors: List[PromptProcessor] = list(prompt_processors or [])
+        self.default_page_size = default_page_size
+        self.strict_ids = strict_ids
+        self.pin_default_branch = pin_default_branch
+        self.idempotency_key_header = idempotency_key_header
+        self._activities = AsyncActivitiesAPI(client, default_page_size, strict_ids)
+        self._sources = AsyncSourcesAPI(client, default_page_size, strict_ids)
+        self.create_throttle: Optional[CreateThrottle] = None
+        if max_creates_per_minute is not None:
+            self.create_throttle = CreateThrottle(max_creates_per_minute)
+
+    def add_create_interceptor(self, interceptor: CreateInterceptor) -> None:
+        """Append an interceptor to the create request chain."""
+        self.create_interceptors.append(interceptor)
+
+    def add_prompt_processor(self, processor: PromptProcessor) -> None:
+        """Append a processor to the chain applied to outgoing prompts and messages."""
+        self.prompt_processors.append(processor)
 
     async def create(
         self,
@@ -20,108 +130,370 @@ class AsyncSessionsAPI:
         source: str,
         starting_branch: Optional[str] = None,
         title: Optional[str] = None,
-        require_plan_approval: bool = False,
+        require_plan_approval: Optional[bool] = None,
+        validate_branch: bool = False,
+        pin_default_branch: Optional[bool] = None,
+        idempotency_key: Optional[str] = None,
     ) -> Session:
         """Create a new session asynchronously."""
+        headers, idempotency_key = _idempotency_headers(
+            self.idempotency_key_header, idempotency_key
+        )
+        source = await self._sources.resolve_name(source)
         data: Dict[str, Any] = {
-            "prompt": prompt,
+            "prompt": apply_processors(prompt, self.prompt_processors),
             "sourceContext": {"source": source},
         }
 
         if starting_branch:
             data["sourceContext"]["githubRepoContext"] = {"startingBranch": starting_branch}
 
+        if pin_default_branch is None:
+            pin_default_branch = self.pin_default_branch
+
+        if pin_default_branch and not starting_branch:
+            await self._pin_default_branch(data["sourceContext"])
+
         if title:
             data["title"] = title
 
+        if require_plan_approval is None:
+            require_plan_approval = self.default_require_plan_approval
+
         if require_plan_approval:
             data["requirePlanApproval"] = require_plan_approval
 
-        response = await self.client.post("sessions", json=data)
-        return Session.from_dict(response)
+        for interceptor in self.create_interceptors:
+            interceptor(data)
+
+        if self.max_prompt_tokens is not None:
+            check_prompt(data["prompt"], self.max_prompt_tokens)
+
+        if validate_branch:
+            await self._validate_branch(data["sourceContext"])
+
+        if self.create_throttle is not None:
+            delay = self.create_throttle.reserve()
+            if delay > 0:
+                await asyncio.sleep(delay)
+
+        with use_headers(headers):
+            response = await self.client.post("sessions", json=data)
+        session = decode(Session, response, self.client.decode_options)
+        session.idempotency_key = idempotency_key
+        return session
+
+    async def _pin_default_branch(self, source_context: Dict[str, Any]) -> None:
+        """Set a create request's starting branch to its source's default asynchronously."""
+        branch = (await self._sources.get(source_context["source"])).default_branch_name
+        if branch:
+            source_context["githubRepoContext"] = {"startingBranch": branch}
+
+    async def _validate_branch(self, source_context: Dict[str, Any]) -> None:
+        """Check a create request's starting branch exists in its source asynchronously."""
+        branch = source_context.get("githubRepoContext", {}).get("startingBranch")
+        if not branch:
+            return
+
+        github_repo = (await self._sources.get(source_context["source"])).github_repo
+        if github_repo is None or not github_repo.branches:
+            return
+
+        names = [b.display_name for b in github_repo.branches]
+        if branch not in names:
+            suggestions = close_matches(branch, names)
+            raise BranchNotFoundError(branch, source_context["source"], suggestions)
 
     async def get(self, session_id: str) -> Session:
         """Get a single session by ID asynchronously."""
-        if not session_id.startswith("sessions/"):
-            session_id = f"sessions/{session_id}"
+        session_id = session_path(session_id, self.strict_ids)
 
         response = await self.client.get(session_id)
-        return Session.from_dict(response)
+        return decode(Session, response, self.client.decode_options)
 
     async def list(
         self, page_size: Optional[int] = None, page_token: Optional[str] = None
     ) -> Dict[str, Any]:
         """List all sessions asynchronously."""
-        params: Dict[str, Any] = {}
-        if page_size is not None:
-            params["pageSize"] = page_size
-        if page_token:
-            params["pageToken"] = page_token
+        params = list_params(page_size, page_token, self.default_page_size)
 
         response = await self.client.get("sessions", params=params)
 
         sessions = []
         if response.get("sessions"):
-            sessions = [Session.from_dict(s) for s in response["sessions"]]
+            options = self.client.decode_options
+            sessions = [decode(Session, s, options) for s in response["sessions"]]
 
         return {
             "sessions": sessions,
             "nextPageToken": response.get("nextPageToken"),
         }
 
-    async def approve_plan(self, session_id: str) -> None:
-        """Approve a plan in a session asynchronously."""
-        if not session_id.startswith("sessions/"):
-            session_id = f"sessions/{session_id}"
-
-        await self.client.post(f"{session_id}:approvePlan")
+    async def approve_plan(
+        self, session_id: str, plan_id: Optional[str] = None, max_attempts: int = 3
+    ) -> None:
+        """Approve a plan in a session asynchronously, see SessionsAPI.approve_plan."""
+        session_id = session_path(session_id, self.strict_ids)
+
+        delay = 0.0
+        for attempt in range(1, max_attempts + 1):
+            try:
+                await self.client.post(f"{session_id}:approvePlan")
+                return
+            except JulesAPIError as e:
+                if plan_id is None or e.status_code not in CONFLICT_STATUSES:
+                    raise
+                if (await self.get(session_id)).state != SessionState.AWAITING_PLAN_APPROVAL:
+                    raise
+                plan = await self._latest_plan(session_id)
+                if plan is None or plan.id != plan_id:
+                    raise PlanSupersededError(session_id, plan_id, plan) from e
+                if attempt == max_attempts:
+                    raise
+                delay = CONFLICT_BACKOFF.next_delay(e, attempt, delay)
+                logger.info(f"Approval of plan {plan_id} conflicted; retrying in {delay}s")
+                await asyncio.sleep(delay)
+
+    async def _latest_plan(self, session_id: str) -> Optional[Plan]:
+        """Find the most recently generated plan of a session asynchronously."""
+        activities = await self._activities.list_all(session_id)
+        plans = [a.plan for a in activities if a.plan is not None]
+        return plans[-1] if plans else None
+
+    async def approve_plan_and_wait(
+        self,
+        session_id: str,
+        plan_id: Optional[str] = None,
+        transition_timeout: float = DEFAULT_TRANSITION_TIMEOUT,
+        **wait_kwargs: Any,
+    ) -> Session:
+        """Approve a plan and wait for completion asynchronously, see SessionsAPI."""
+        session_id = session_path(session_id, self.strict_ids)
+        await self.approve_plan(session_id, plan_id=plan_id)
+
+        clock = asyncio.get_event_loop().time
+        start_time = clock()
+        while (await self.get(session_id)).state == SessionState.AWAITING_PLAN_APPROVAL:
+            if plan_id is not None:
+                plan = await self._latest_plan(session_id)
+                if plan is not None and plan.id != plan_id:
+                    logger.info(f"Plan {plan_id} was approved and replaced by {plan.id}")
+                    break
+            waited = clock() - start_time
+            if waited > transition_timeout:
+                raise SessionStalledError(session_id, "AWAITING_PLAN_APPROVAL", waited)
+            await asyncio.sleep(TRANSITION_POLL_INTERVAL)
+
+        wait_kwargs.setdefault("last_known_state", SessionState.AWAITING_PLAN_APPROVAL)
+        return await self.wait_for_completion(session_id, **wait_kwargs)
 
     async def send_message(self, session_id: str, prompt: str) -> None:
         """Send a message from the user to a session asynchronously."""
-        if not session_id.startswith("sessions/"):
-            session_id = f"sessions/{session_id}"
+        session_id = session_path(session_id, self.strict_ids)
+
+        prompt = apply_processors(prompt, self.prompt_processors)
+        if self.max_prompt_tokens is not None:
+            check_prompt(prompt, self.max_prompt_tokens)
 
         await self.client.post(f"{session_id}:sendMessage", json={"prompt": prompt})
 
+    async def _last_agent_activity(self, session_id: str) -> Optional[Activity]:
+        """Find the most recent activity carrying an agent message asynchronously."""
+        activities = await self._activities.list_all(session_id)
+        messages = [a for a in activities if a.agent_messaged is not None]
+        return messages[-1] if messages else None
+
+    async def last_agent_message(self, session_id: str) -> Tuple[str, Optional[datetime]]:
+        """Get the most recent agent message text and its create time asynchronously."""
+        activity = await self._last_agent_activity(session_id)
+        if activity is None:
+            return "", None
+        return activity.agent_message, parse_timestamp(activity.create_time)
+
+    async def retry_failed(
+        self, session_id: str, include_failure_reason: bool = True
+    ) -> Session:
+        """Start a new session repeating the task of a failed one asynchronously."""
+        original = await self.get(session_id)
+        if original.state != SessionState.FAILED:
+            raise JulesValidationError(
+                f"Only failed sessions can be retried; {original.name or session_id} "
+                f"is {original.state.value}"
+            )
+
+        prompt = original.prompt
+        if include_failure_reason:
+            reason = ""
+            for activity in await self._activities.list_all(session_id):
+                if activity.session_failed:
+                    reason = activity.failure_reason
+            prompt += f"\n\nA previous attempt ({original.name or session_id}) failed"
+            prompt += f": {reason}" if reason else "."
+
+        repo_context = original.source_context.github_repo_context
+        return await self.create(
+            prompt=prompt,
+            source=original.source_context.source,
+            starting_branch=repo_context.starting_branch if repo_context else None,
+            title=original.title or None,
+            require_plan_approval=original.require_plan_approval,
+        )
+
+    async def get_completion_details(self, session_id: str) -> CompletionDetails:
+        """Get the outcome of a completed session asynchronously."""
+        session = await self.get(session_id)
+        activities = await self._activities.list_all(session_id)
+        return CompletionDetails.from_session(session, activities)
+
+    async def get_progress(self, session_id: str) -> Optional[PlanProgress]:
+        """Get how far a session has got through its plan asynchronously."""
+        return PlanProgress.from_activities(await self._activities.list_all(session_id))
+
+    async def artifacts(self, session_id: str) -> ArtifactIndex:
+        """Get an index of all artifacts produced by a session asynchronously."""
+        return ArtifactIndex.from_activities(await self._activities.list_all(session_id))
+
     async def wait_for_completion(
-        self, session_id: str, poll_interval: int = 5, timeout: Optional[int] = None
+        self,
+        session_id: str,
+        poll_interval: int = 5,
+        timeout: Optional[int] = None,
+        on_feedback_requested: Optional[AsyncFeedbackHandler] = None,
+        last_known_state: Optional[SessionState] = None,
+        on_state_change: Optional[AsyncStateChangeHandler] = None,
+        queued_timeout: Optional[float] = None,
+        on_queued_stall: Optional[AsyncQueuedStallHandler] = None,
+        max_inactivity: Optional[float] = None,
+        on_inactive: Optional[AsyncInactivityHandler] = None,
+        cancel_event: Optional[asyncio.Event] = None,
+        not_found_grace: Optional[float] = None,
+        stats: Optional[WaitStats] = None,
+        max_polls: Optional[int] = None,
     ) -> Session:
         """Poll a session asynchronously until it completes or fails."""
-        start_time = asyncio.get_event_loop().time()
+        clock = asyncio.get_event_loop().time
+        start_time = clock()
         terminal_states = {
             SessionState.COMPLETED,
             SessionState.FAILED,
         }
-
-        while True:
-            session = await self.get(session_id)
-
-            if session.state in terminal_states:
-                if session.state == SessionState.FAILED:
-                    raise JulesAPIError(f"Session failed: {session_id}")
-                return session
-
-            if timeout and (asyncio.get_event_loop().time() - start_time) > timeout:
-                raise TimeoutError(f"Session polling timed out after {timeout} seconds")
-
-            await asyncio.sleep(poll_interval)
+        answered: Optional[str] = None
+        state = last_known_state
+        queued_since: Optional[float] = None
+        stall_reported = False
+        update_time: Optional[str] = None
+        active_at = start_time
+        polls = 0
+
+        with _track_wait(stats, start_time, clock):
+            while True:
+                if cancel_event is not None and cancel_event.is_set():
+                    raise WaitCancelledError(
+                        session_path(session_id, self.strict_ids),
+                        getattr(cancel_event, "reason", None),
+                    )
+                if max_polls is not None and polls >= max_polls:
+                    raise PollLimitExceededError(
+                        session_path(session_id, self.strict_ids), max_polls
+                    )
+                polls += 1
+                if stats is not None:
+                    stats.polls = polls
+                try:
+                    session = await self.get(session_id)
+                except JulesNotFoundError:
+                    elapsed = asyncio.get_event_loop().time() - start_time
+                    if not not_found_grace or elapsed > not_found_grace:
+                        raise
+                    await _pause(poll_interval, cancel_event)
+                    continue
+
+                changed = session.update_time != update_time or session.state != state
+                if max_inactivity and changed:
+                    update_time = session.update_time
+                    active_at = asyncio.get_event_loop().time()
+
+                if session.state != state:
+                    await self.client._notify(on_state_change, session, state)
+                    state = session.state
+
+                if session.state in terminal_states:
+                    if session.state == SessionState.FAILED:
+                        raise JulesAPIError(f"Session failed: {session_id}")
+                    return session
+
+                if session.state != SessionState.QUEUED:
+                    queued_since = None
+                elif queued_since is None:
+                    queued_since = asyncio.get_event_loop().time()
+                elif queued_timeout and not stall_reported:
+                    queued_for = asyncio.get_event_loop().time() - queued_since
+                    if queued_for > queued_timeout:
+                        if on_queued_stall is None:
+                            raise SessionStalledError(
+                                session.name or session_id, "QUEUED", queued_for
+                            )
+                        stall_reported = True
+                        replacement = on_queued_stall(session, queued_for)
+                        if inspect.isawaitable(replacement):
+                            replacement = await replacement
+                        if replacement is not None:
+                            logger.info(f"Replacing stalled {session_id} with {replacement.name}")
+                            session_id = replacement.name or replacement.id
+                            state, queued_since, stall_reported = None, None, False
+                            continue
+
+                if max_inactivity and session.state == SessionState.IN_PROGRESS:
+                    inactive_for = asyncio.get_event_loop().time() - active_at
+                    if inactive_for > max_inactivity:
+                        if on_inactive is None:
+                            raise SessionStalledError(
+                                session.name or session_id, "IN_PROGRESS", inactive_for
+                            )
+                        await self.client._notify(on_inactive, session, inactive_for)
+                        active_at = asyncio.get_event_loop().time()
+
+                if (
+                    on_feedback_requested is not None
+                    and session.state == SessionState.AWAITING_USER_FEEDBACK
+                ):
+                    question = await self._last_agent_activity(session_id)
+                    if question is not None and question.name != answered:
+                        message = question.agent_message
+                        reply = on_feedback_requested(session, message)
+                        if inspect.isawaitable(reply):
+                            reply = await reply
+                        answered = question.name
+                        if reply:
+                            await self.send_message(session_id, reply)
+                            continue
+
+                elapsed = asyncio.get_event_loop().time() - start_time
+                if timeout and elapsed > timeout:
+                    raise JulesTimeoutError(session.name or session_id, timeout, elapsed)
+
+                await _pause(poll_interval, cancel_event)
 
 
 class AsyncActivitiesAPI:
     """Async API client for managing session activities."""
 
-    def __init__(self, client: AsyncBaseClient) -> None:
+    def __init__(
+        self,
+        client: AsyncBaseClient,
+        default_page_size: Optional[int] = None,
+        strict_ids: bool = False,
+    ) -> None:
         """Initialize the async Activities API."""
         self.client = client
+        self.default_page_size = default_page_size
+        self.strict_ids = strict_ids
 
     async def get(self, session_id: str, activity_id: str) -> Activity:
         """Get a single activity by ID asynchronously."""
-        if not session_id.startswith("sessions/"):
-            session_id = f"sessions/{session_id}"
-
-        path = f"{session_id}/activities/{activity_id}"
+        path = activity_path(session_id, activity_id, self.strict_ids)
         response = await self.client.get(path)
-        return Activity.from_dict(response)
+        return decode(Activity, response, self.client.decode_options)
 
     async def list(
         self,
@@ -130,37 +502,39 @@ class AsyncActivitiesAPI:
         page_token: Optional[str] = None,
     ) -> Dict[str, Any]:
         """List activities for a session asynchronously."""
-        if not session_id.startswith("sessions/"):
-            session_id = f"sessions/{session_id}"
+        session_id = session_path(session_id, self.strict_ids)
 
-        params: Dict[str, Any] = {}
-        if page_size is not None:
-            params["pageSize"] = page_size
-        if page_token:
-            params["pageToken"] = page_token
+        params = list_params(page_size, page_token, self.default_page_size)
 
         path = f"{session_id}/activities"
         response = await self.client.get(path, params=params)
 
         activities = []
         if response.get("activities"):
-            activities = [Activity.from_dict(a) for a in response["activities"]]
+            options = self.client.decode_options
+            activities = [decode(Activity, a, options) for a in response["activities"]]
 
         return {
             "activities": activities,
             "nextPageToken": response.get("nextPageToken"),
         }
 
-    async def list_all(self, session_id: str) -> List[Activity]:
+    async def list_all(
+        self,
+        session_id: str,
+        page_size: Optional[int] = None,
+        max_pages: Optional[int] = DEFAULT_MAX_PAGES,
+    ) -> List[Activity]:
         """List all activities for a session asynchronously (handles pagination)."""
         all_activities: List[Activity] = []
         page_token: Optional[str] = None
+        tracker = PageTracker(f"activities of {session_id}", max_pages)
 
         while True:
-            result = await self.list(session_id, page_token=page_token)
+            result = await self.list(session_id, page_size=page_size, page_token=page_token)
             all_activities.extend(result["activities"])
 
-            page_token = result.get("nextPageToken")
+            page_token = tracker.advance(result.get("nextPageToken"))
             if not page_token:
                 break
 
@@ -170,58 +544,251 @@ class AsyncActivitiesAPI:
 class AsyncSourcesAPI:
     """Async API client for managing Jules sources."""
 
-    def __init__(self, client: AsyncBaseClient) -> None:
+    def __init__(
+        self,
+        client: AsyncBaseClient,
+        default_page_size: Optional[int] = None,
+        strict_ids: bool = False,
+    ) -> None:
         """Initialize the async Sources API."""
         self.client = client
+        self.default_page_size = default_page_size
+        self.strict_ids = strict_ids
 
     async def get(self, source_id: str) -> Source:
         """Get a single source by ID asynchronously."""
-        if not source_id.startswith("sources/"):
-            source_id = f"sources/{source_id}"
+        if is_repo_shorthand(source_id):
+            return await self.resolve(source_id)
+        source_id = source_path(source_id, self.strict_ids)
 
         response = await self.client.get(source_id)
-        return Source.from_dict(response)
+        return decode(Source, response, self.client.decode_options)
 
     async def list(
         self,
-        filter_str: Optional[str] = None,
+        filter_str: Optional[SourceFilterLike] = None,
         page_size: Optional[int] = None,
         page_token: Optional[str] = None,
     ) -> Dict[str, Any]:
         """List sources asynchronously."""
-        params: Dict[str, Any] = {}
-        if filter_str:
-            params["filter"] = filter_str
-        if page_size is not None:
-            params["pageSize"] = page_size
-        if page_token:
-            params["pageToken"] = page_token
+        expression, source_filter = split_filter(filter_str)
+        params = list_params(page_size, page_token, self.default_page_size, expression)
 
         response = await self.client.get("sources", params=params)
 
         sources = []
         if response.get("sources"):
-            sources = [Source.from_dict(s) for s in response["sources"]]
+            options = self.client.decode_options
+            sources = [decode(Source, s, options) for s in response["sources"]]
+            if source_filter is not None:
+                sources = [s for s in sources if source_filter.matches(s)]
 
         return {
             "sources": sources,
             "nextPageToken": response.get("nextPageToken"),
         }
 
-    async def list_all(self, filter_str: Optional[str] = None) -> List[Source]:
+    async def list_all(
+        self,
+        filter_str: Optional[SourceFilterLike] = None,
+        page_size: Optional[int] = None,
+        max_pages: Optional[int] = DEFAULT_MAX_PAGES,
+    ) -> List[Source]:
         """List all sources asynchronously (handles pagination)."""
-        all_sources: List[Source] = []
+        return [
+            source
+            async for source in self.iter_all(
+                filter_str=filter_str, page_size=page_size, max_pages=max_pages
+            )
+        ]
+
+    async def iter_all(
+        self,
+        filter_str: Optional[SourceFilterLike] = None,
+        page_size: Optional[int] = None,
+        max_pages: Optional[int] = DEFAULT_MAX_PAGES,
+    ) -> AsyncIterator[Source]:
+        """Iterate over all sources asynchronously, fetching pages as they are consumed."""
         page_token: Optional[str] = None
+        tracker = PageTracker("sources", max_pages)
 
         while True:
-            result = await self.list(filter_str=filter_str, page_token=page_token)
-            all_sources.extend(result["sources"])
+            result = await self.list(
+                filter_str=filter_str, page_size=page_size, page_token=page_token
+            )
+            for source in result["sources"]:
+                yield source
 
-            page_token = result.get("nextPageToken")
+            page_token = tracker.advance(result.get("nextPageToken"))
             if not page_token:
                 break
 
-        return all_sources
+    async def iter_filter(
+        self,
+        predicate: Callable[[Source], bool],
+        filter_str: Optional[SourceFilterLike] = None,
+        page_size: Optional[int] = None,
+        max_pages: Optional[int] = DEFAULT_MAX_PAGES,
+    ) -> AsyncIterator[Source]:
+        """Iterate over the sources matching a client-side predicate asynchronously."""
+        async for source in self.iter_all(
+            filter_str=filter_str, page_size=page_size, max_pages=max_pages
+        ):
+            if predicate(source):
+                yield source
+
+    async def resolve(self, name_or_repo: str) -> Source:
+        """Find a source by resource name, source ID or GitHub "owner/repo" asynchronously."""
+        value = name_or_repo.strip()
+        if not is_repo_shorthand(value):
+            return await self.get(value)
+
+        name = self.client.source_names.get(value)
+        if name is not None:
+            try:
+                return await self.get(name)
+            except JulesNotFoundError:
+                self.client.source_names.forget(value)
+        return await self._find_repo(value)
+
+    async def resolve_name(self, identifier: str) -> str:
+        """Normalize any source identifier to its full resource name asynchronously."""
+        value = identifier.strip()
+        if not is_repo_shorthand(value):
+            return source_path(value, self.strict_ids)
+        return self.client.source_names.get(value) or (await self._find_repo(value)).name
+
+    def clear_cache(self) -> None:
+        """Forget memoized "owner/repo" lookups."""
+        self.client.source_names.clear()
+
+    async def _find_repo(self, repo: str) -> Source:
+        """Scan all sources for a GitHub repository, memoizing every name seen."""
+        owner, name = repo.split("/")
+        sources = await self.list_all()
+        self.client.source_names.update(sources)
+
+        candidates: List[str] = []
+        for source in sources:
+            github_repo = source.github_repo
+            if github_repo:
+                if github_repo.owner == owner and github_repo.repo == name:
+                    return source
+                candidates.append(f"{github_repo.owner}/{github_repo.repo}")
+
+        raise SourceNotFoundError(repo, close_matches(repo, candidates))
+
+
+class AsyncSessionHandle:
+    """Async session-scoped view of the client."""
+
+    def __init__(
+        self,
+        sessions: AsyncSessionsAPI,
+        activities: AsyncActivitiesAPI,
+        name: str,
+        log_fields: Optional[Dict[str, Any]] = None,
+    ) -> None:
+        """Initialize the async handle."""
+        self.sessions = sessions
+        self.activities_api = activities
+        self.name = name
+        self.id = name.split("/", 1)[1]
+        self.logger = logging.LoggerAdapter(
+            logger, {"session_id": self.id, **(log_fields or {})}
+        )
+
+    def __repr__(self) -> str:
+        """Show the bound session."""
+        return f"AsyncSessionHandle({self.name})"
+
+    async def get(self) -> Session:
+        """Get the session asynchronously."""
+        return await self.sessions.get(self.name)
+
+    async def approve_plan(self, plan_id: Optional[str] = None) -> None:
+        """Approve the session's pending plan asynchronously."""
+        self.logger.info(f"Approving plan for {self.name}")
+        await self.sessions.approve_plan(self.name, plan_id=plan_id)
+
+    async def approve_plan_and_wait(self, plan_id: Optional[str] = None, **kwargs: Any) -> Session:
+        """Approve the session's pending plan and wait for completion asynchronously."""
+        self.logger.info(f"Approving plan for {self.name}")
+        return await self.sessions.approve_plan_and_wait(self.name, plan_id=plan_id, **kwargs)
+
+    async def send_message(self, prompt: str) -> None:
+        """Send a message from the user to the session asynchronously."""
+        self.logger.info(f"Sending message to {self.name}")
+        await self.sessions.send_message(self.name, prompt)
+
+    async def activities(self, page_size: Optional[int] = None) -> List[Activity]:
+        """List all activities of the session asynchronously."""
+        return await self.activities_api.list_all(self.name, page_size=page_size)
+
+    async def last_agent_message(self) -> Tuple[str, Optional[datetime]]:
+        """Get the most recent agent message and its create time asynchronously."""
+        return await self.sessions.last_agent_message(self.name)
+
+    async def completion_details(self) -> CompletionDetails:
+        """Get the outputs and summary of the completed session asynchronously."""
+        return await self.sessions.get_completion_details(self.name)
+
+    async def progress(self) -> Optional[PlanProgress]:
+        """Get how far the session has got through its plan asynchronously."""
+        return await self.sessions.get_progress(self.name)
+
+    async def artifacts(self) -> ArtifactIndex:
+        """Get an index of all artifacts produced by the session asynchronously."""
+        return await self.sessions.artifacts(self.name)
+
+    async def wait(self, **kwargs: Any) -> Session:
+        """Poll the session asynchronously until it completes or fails."""
+        self.logger.debug(f"Waiting for {self.name}")
+        return await self.sessions.wait_for_completion(self.name, **kwargs)
+
+
+class AsyncSourceHandle:
+    """Async source-scoped view of the client."""
+
+    def __init__(
+        self, sources: AsyncSourcesAPI, sessions: AsyncSessionsAPI, source: Source
+    ) -> None:
+        """Initialize the async handle."""
+        self.sources = sources
+        self.sessions = sessions
+        self.source = source
+        self.name = source.name
+
+    def __repr__(self) -> str:
+        """Show the bound source."""
+        return f"AsyncSourceHandle({self.name})"
+
+    async def create_session(self, prompt: str, **kwargs: Any) -> Session:
+        """Create a session on this source asynchronously."""
+        return await self.sessions.create(prompt=prompt, source=self.name, **kwargs)
+
+    async def list_sessions(self, max_pages: Optional[int] = DEFAULT_MAX_PAGES) -> List[Session]:
+        """List the sessions started on this source asynchronously."""
+        matches: List[Session] = []
+        page_token: Optional[str] = None
+        tracker = PageTracker("sessions", max_pages)
+
+        while True:
+            result = await self.sessions.list(page_token=page_token)
+            matches.extend(s for s in result["sessions"] if s.source_context.source == self.name)
+
+            page_token = tracker.advance(result.get("nextPageToken"))
+            if not page_token:
+                break
+
+        return matches
+
+    async def list_branches(self) -> List[str]:
+        """List the branch names of the source's GitHub repository asynchronously."""
+        self.source = await self.sources.get(self.name)
+        if not self.source.github_repo:
+            return []
+        return [b.display_name for b in self.source.github_repo.branches]
 
 
 class AsyncJulesClient:
@@ -258,12 +825,83 @@ class AsyncJulesClient:
         sources: Async API client for source operations
     """
 
-    def __init__(self, api_key: str, base_url: Optional[str] = None) -> None:
+    def __init__(
+        self,
+        api_key: str,
+        base_url: Optional[str] = None,
+        default_require_plan_approval: bool = False,
+        create_interceptors: Optional[List[CreateInterceptor]] = None,
+        max_prompt_tokens: Optional[int] = None,
+        connector_kwargs: Optional[Dict[str, Any]] = None,
+        max_connections: int = 100,
+        max_connections_per_host: int = 0,
+        default_page_size: Optional[int] = None,
+        strict_ids: bool = False,
+        extra_headers: Optional[Dict[str, str]] = None,
+        correlation_id_extractor: Optional[Callable[[], Optional[str]]] = current_correlation_id,
+        correlation_id_header: str = DEFAULT_CORRELATION_ID_HEADER,
+        max_creates_per_minute: Optional[int] = None,
+        read_only: bool = False,
+        decode_options: Optional[DecodeOptions] = None,
+        prompt_processors: Optional[List[PromptProcessor]] = None,
+        pin_default_branch: bool = False,
+        idempotency_key_header: Optional[str] = DEFAULT_IDEMPOTENCY_KEY_HEADER,
+        raise_callback_errors: bool = False,
+        max_retry_after: float = DEFAULT_MAX_RETRY_AFTER,
+    ) -> None:
         """Initialize the async Jules API client.
 
         Args:
             api_key: Your Jules API key for authentication
             base_url: Optional custom base URL
+            default_require_plan_approval: Require plan approval for every session
+                created through this client unless overridden per call
+            create_interceptors: Callables applied to every create request body;
+                each may mutate the body or raise to reject the request
+            max_prompt_tokens: Optional estimated token limit checked locally before
+                prompts and messages are sent
+            connector_kwargs: Options for aiohttp.TCPConnector (resolver, family,
+                local_addr, keepalive_timeout, ...)
+            max_connections: Maximum simultaneous connections (default: 100, 0 for
+                no limit)
+            max_connections_per_host: Maximum simultaneous connections per host
+                (default: 0, no limit)
+            default_page_size: Page size for list calls that do not specify one
+                (default: server default, capped at 100)
+            strict_ids: Reject URLs, whitespace and other ambiguous IDs instead of
+                normalizing them (default: False)
+            extra_headers: Headers added to every request, for API gateways that
+                require auth tokens, correlation IDs or cost-center tags. Use
+                jules_agent_sdk.use_headers() for per-call headers
+            correlation_id_extractor: Called once per request to get a trace or
+                correlation ID, which is sent as a header and attached to logs and
+                errors (default: the ID set with use_correlation_id(); None disables)
+            correlation_id_header: Header carrying the correlation ID
+                (default: "X-Correlation-ID")
+            max_creates_per_minute: Limit on sessions created per minute by this
+                client, for fan-outs; calls over the limit wait locally rather than
+                fail (default: no limit)
+            read_only: Block every call that could create, message or approve
+                anything with ReadOnlyModeError, for dashboards and analytics
+                (default: False)
+            decode_options: Response decoding strictness: Decimal numbers, RFC 3339
+                timestamp checks and unknown-field rejection (default: lenient)
+            prompt_processors: Callables applied, in order, to every prompt and
+                message before it is sent, e.g. prompt.normalize_whitespace or
+                prompt.scrub_emails
+            pin_default_branch: When a session is created without a starting
+                branch, send the source's current default branch, so the session
+                records the exact base it started from (default: False)
+            idempotency_key_header: Header that create() sends its idempotency key
+                in, so a create retried after a network error does not start a
+                duplicate session; None sends no key (default: "Idempotency-Key")
+            raise_callback_errors: Propagate exceptions raised by notification
+                callbacks such as on_state_change instead of logging them
+                (default: False)
+            max_retry_after: Longest Retry-After delay in seconds the client waits
+                out itself when rate limited (429); longer delays raise
+                JulesRateLimitError right away, and 0 disables the retry
+                (default: 60)
 
         Raises:
             ValueError: If api_key is empty or None
@@ -271,10 +909,50 @@ class AsyncJulesClient:
         if not api_key:
             raise ValueError("API key is required")
 
-        self._base_client = AsyncBaseClient(api_key=api_key, base_url=base_url)
-        self.sessions = AsyncSessionsAPI(self._base_client)
-        self.activities = AsyncActivitiesAPI(self._base_client)
-        self.sources = AsyncSourcesAPI(self._base_client)
+        self._base_client = AsyncBaseClient(
+            api_key=api_key,
+            base_url=base_url,
+            connector_kwargs=connector_kwargs,
+            max_connections=max_connections,
+            max_connections_per_host=max_connections_per_host,
+            extra_headers=extra_headers,
+            correlation_id_extractor=correlation_id_extractor,
+            correlation_id_header=correlation_id_header,
+            read_only=read_only,
+            decode_options=decode_options,
+            raise_callback_errors=raise_callback_errors,
+            max_retry_after=max_retry_after,
+        )
+        self.sessions = AsyncSessionsAPI(
+            self._base_client,
+            default_require_plan_approval=default_require_plan_approval,
+            create_interceptors=create_interceptors,
+            max_prompt_tokens=max_prompt_tokens,
+            default_page_size=default_page_size,
+            strict_ids=strict_ids,
+            max_creates_per_minute=max_creates_per_minute,
+            prompt_processors=prompt_processors,
+            pin_default_branch=pin_default_branch,
+            idempotency_key_header=idempotency_key_header,
+        )
+        self.activities = AsyncActivitiesAPI(self._base_client, default_page_size, strict_ids)
+        self.sources = AsyncSourcesAPI(self._base_client, default_page_size, strict_ids)
+
+    def session(
+        self, session_id: str, log_fields: Optional[Dict[str, Any]] = None
+    ) -> AsyncSessionHandle:
+        """Get an async handle bound to one session."""
+        name = session_path(session_id, self.sessions.strict_ids)
+        return AsyncSessionHandle(self.sessions, self.activities, name, log_fields)
+
+    async def source(self, name_or_repo: str) -> AsyncSourceHandle:
+        """Resolve a source and get an async handle bound to it."""
+        source = await self.sources.resolve(name_or_repo)
+        return AsyncSourceHandle(self.sources, self.sessions, source)
+
+    def deprecation_notices(self) -> List[DeprecationNotice]:
+        """Get the endpoints the server has announced as deprecated."""
+        return self._base_client.deprecations.notices()
 
     async def close(self) -> None:
         """Close the HTTP session."""
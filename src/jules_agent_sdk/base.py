@@ -1,19 +1,58 @@
 """Base HTTP client for Jules API with retries, timeouts, and logging."""
 
+import json as jsonlib
 import time
 import logging
-import json
-from typing import Optional, Dict, Any
+import socket
+from datetime import datetime, timezone
+from email.utils import parsedate_to_datetime
+from decimal import Decimal
+from typing import Optional, Dict, Any, Iterable, List, Callable, Mapping, TextIO, Tuple, Union
 import requests
+from requests.adapters import HTTPAdapter
 from requests.exceptions import RequestException, Timeout, ConnectionError
+from urllib.parse import urlsplit
+from urllib3.connection import HTTPConnection
 
 from jules_agent_sdk.exceptions import (
+    CircuitOpenError,
     JulesAPIError,
     JulesAuthenticationError,
     JulesNotFoundError,
     JulesValidationError,
     JulesRateLimitError,
     JulesServerError,
+    ReadOnlyModeError,
+    UnexpectedContentTypeError,
+    is_server_error,
+)
+from jules_agent_sdk.callbacks import invoke_callback
+from jules_agent_sdk.circuit import CircuitBreaker
+from jules_agent_sdk.decoding import DecodeOptions
+from jules_agent_sdk.deprecation import DeprecationTracker
+from jules_agent_sdk.resources import route
+# Transport categories are re-exported from here for existing imports
+from jules_agent_sdk.retry import (  # noqa: F401
+    DEFAULT_MAX_BACKOFF,
+    DEFAULT_MAX_RETRY_AFTER,
+    TRANSPORT_CONNECTION,
+    TRANSPORT_CONNECTION_RESET,
+    TRANSPORT_DNS,
+    TRANSPORT_ERROR_CATEGORIES,
+    TRANSPORT_TIMEOUT,
+    ExponentialBackoff,
+    RetryPolicy,
+    classify_transport_error,
+)
+from jules_agent_sdk.sourcemap import SourceNameCache
+from jules_agent_sdk.wiredump import WireDumper
+from jules_agent_sdk.context import (
+    DEFAULT_CORRELATION_ID_HEADER,
+    attempt_scope,
+    current_api_key,
+    current_correlation_id,
+    current_headers,
+    note_request,
 )
 
 logger = logging.getLogger(__name__)
@@ -23,7 +62,98 @@ logger = logging.getLogger(__name__)
 DEFAULT_TIMEOUT = 30
 DEFAULT_MAX_RETRIES = 3
 DEFAULT_RETRY_BACKOFF_FACTOR = 1.0
-DEFAULT_MAX_BACKOFF = 10.0
+DEFAULT_POOL_CONNECTIONS = 10
+DEFAULT_POOL_MAXSIZE = 20
+
+# Response headers that may carry the server's ID for a request, checked in order
+REQUEST_ID_HEADERS = ("X-Request-Id", "X-Goog-Request-Id")
+
+# Called with (failed base URL, new base URL, error) when the client fails over
+FailoverHandler = Callable[[str, str, Exception], None]
+
+# Returns the correlation ID for the current call, or None to send no header
+CorrelationIdExtractor = Callable[[], Optional[str]]
+
+# Performs one HTTP attempt, called like requests.Session.request(method=..., url=..., ...)
+Doer = Callable[..., requests.Response]
+
+# Wraps a Doer to observe or change every request and response, see BaseClient.use
+Middleware = Callable[[Doer], Doer]
+
+SocketOption = Tuple[int, int, int]
+
+# urllib3 defaults plus TCP keep-alive, for long polls through NAT or idle-killing proxies
+TCP_KEEPALIVE_SOCKET_OPTIONS: List[SocketOption] = HTTPConnection.default_socket_options + [
+    (socket.SOL_SOCKET, socket.SO_KEEPALIVE, 1),
+]
+
+
+def parse_retry_after(value: Optional[str]) -> Optional[float]:
+    """Parse a Retry-After header into seconds from now.
+
+    Args:
+        value: Header value, either delay-seconds or an HTTP date
+
+    Returns:
+        Seconds to wait (0 for dates in the past), or None if missing or invalid
+
+    Example:
+        >>> parse_retry_after("120")
+        120.0
+    """
+    if not value:
+        return None
+    value = value.strip()
+    try:
+        return max(float(value), 0.0)
+    except ValueError:
+        pass
+    try:
+        when = parsedate_to_datetime(value)
+    except (TypeError, ValueError):
+        return None
+    if when.tzinfo is None:
+        when = when.replace(tzinfo=timezone.utc)
+    return max((when - datetime.now(timezone.utc)).total_seconds(), 0.0)
+
+
+def response_request_id(headers: Any) -> Optional[str]:
+    """Get the server's request ID from response headers, if it sent one.
+
+    Args:
+        headers: Response headers
+
+    Returns:
+        The first REQUEST_ID_HEADERS value present, or None
+    """
+    if not isinstance(headers, Mapping):
+        return None
+    for name in REQUEST_ID_HEADERS:
+        value = headers.get(name)
+        if value:
+            return str(value)
+    return None
+
+
+class TransportAdapter(HTTPAdapter):
+    """HTTP adapter that applies custom socket options to pooled connections."""
+
+    def __init__(self, socket_options: Optional[List[SocketOption]] = None, **kwargs: Any) -> None:
+        """Initialize the adapter.
+
+        Args:
+            socket_options: Socket options set on every new connection
+            **kwargs: Passed through to requests' HTTPAdapter
+        """
+        # Must be set first: HTTPAdapter.__init__ builds the pool manager
+        self.socket_options = socket_options
+        super().__init__(**kwargs)
+
+    def init_poolmanager(self, *args: Any, **kwargs: Any) -> None:
+        """Create the pool manager with the configured socket options."""
+        if self.socket_options is not None:
+            kwargs["socket_options"] = self.socket_options
+        super().init_poolmanager(*args, **kwargs)
 
 
 class BaseClient:
@@ -35,6 +165,7 @@ class BaseClient:
     - Comprehensive logging
     - Rate limit handling
     - Connection pooling
+    - Failover to alternate endpoints
     """
 
     BASE_URL = "https://jules.googleapis.com/v1alpha"
@@ -46,6 +177,27 @@ class BaseClient:
         timeout: int = DEFAULT_TIMEOUT,
         max_retries: int = DEFAULT_MAX_RETRIES,
         retry_backoff_factor: float = DEFAULT_RETRY_BACKOFF_FACTOR,
+        fallback_base_urls: Optional[List[str]] = None,
+        on_failover: Optional[FailoverHandler] = None,
+        connect_timeout: Optional[float] = None,
+        socket_options: Optional[List[SocketOption]] = None,
+        transport_adapter: Optional[HTTPAdapter] = None,
+        pool_connections: int = DEFAULT_POOL_CONNECTIONS,
+        pool_maxsize: int = DEFAULT_POOL_MAXSIZE,
+        pool_block: bool = False,
+        raise_callback_errors: bool = False,
+        extra_headers: Optional[Dict[str, str]] = None,
+        correlation_id_extractor: Optional[CorrelationIdExtractor] = current_correlation_id,
+        correlation_id_header: str = DEFAULT_CORRELATION_ID_HEADER,
+        read_only: bool = False,
+        decode_options: Optional[DecodeOptions] = None,
+        retry_transport_errors: Iterable[str] = TRANSPORT_ERROR_CATEGORIES,
+        stats_path: Optional[str] = None,
+        middlewares: Optional[List[Middleware]] = None,
+        wire_dump: Optional[TextIO] = None,
+        max_retry_after: float = DEFAULT_MAX_RETRY_AFTER,
+        retry_policy: Optional[RetryPolicy] = None,
+        circuit_breaker: Optional[CircuitBreaker] = None,
     ) -> None:
         """Initialize the base client.
 
@@ -55,16 +207,85 @@ class BaseClient:
             timeout: Request timeout in seconds
             max_retries: Maximum number of retry attempts
             retry_backoff_factor: Backoff factor for retries (exponential)
+            fallback_base_urls: Alternate base URLs tried in order once retries
+                against the current endpoint are exhausted by 5xx or network errors
+            on_failover: Optional callback invoked whenever the client fails over
+            connect_timeout: Optional separate timeout in seconds for establishing
+                connections, including the TLS handshake (defaults to timeout)
+            socket_options: Socket options for new connections, e.g.
+                TCP_KEEPALIVE_SOCKET_OPTIONS
+            transport_adapter: Custom requests adapter mounted for http and https,
+                for full control over DNS resolution and connection setup
+            pool_connections: Number of per-host connection pools to cache
+            pool_maxsize: Maximum connections kept open per host
+            pool_block: If True, cap concurrent connections per host at pool_maxsize
+                and make callers wait for a free connection instead of opening more
+            raise_callback_errors: Propagate exceptions from notification callbacks
+                such as on_failover instead of logging them
+            extra_headers: Headers sent with every request, e.g. gateway auth
+                tokens or cost-center tags
+            correlation_id_extractor: Called once per request to get a correlation
+                ID to send and attach to logs and errors (defaults to the ID set
+                with use_correlation_id(); None disables)
+            correlation_id_header: Header carrying the correlation ID
+            read_only: Reject every non-GET request with ReadOnlyModeError
+            decode_options: Strictness of response decoding (default: lenient)
+            retry_transport_errors: Transport error categories that are retried,
+                e.g. without TRANSPORT_TIMEOUT to avoid resending a request the
+                server may already be processing (default: all)
+            stats_path: File that a JSON line stats snapshot is appended to on
+                close(), so short-lived processes leave usage statistics behind
+            middlewares: Middlewares wrapping every HTTP attempt, outermost first
+            wire_dump: Text stream every HTTP attempt is dumped to in full, with
+                credential headers redacted
+            max_retry_after: Longest Retry-After delay in seconds that a 429 is
+                retried after; longer delays raise JulesRateLimitError at once
+                (0 disables retrying rate limited requests)
+            retry_policy: Policy deciding which failures are retried and how long
+                to wait, replacing max_retries, retry_backoff_factor,
+                retry_transport_errors and max_retry_after (see retry.py)
+            circuit_breaker: Breaker that fails fast with CircuitOpenError while
+                an endpoint keeps returning 5xx or network errors; may be
+                shared between clients
         """
         self.api_key = api_key
+        self.decode_options = decode_options or DecodeOptions()
+        # Shared by every API object of the client, see SourcesAPI.resolve_name
+        self.source_names = SourceNameCache()
+        self.read_only = read_only
+        self.correlation_id_extractor = correlation_id_extractor
+        self.correlation_id_header = correlation_id_header
         self.base_url = base_url or self.BASE_URL
+        self.base_urls = [self.base_url] + list(fallback_base_urls or [])
+        self.on_failover = on_failover
+        self.raise_callback_errors = raise_callback_errors
         self.timeout = timeout
+        self.connect_timeout = connect_timeout
         self.max_retries = max_retries
         self.retry_backoff_factor = retry_backoff_factor
+        self.retry_transport_errors = frozenset(retry_transport_errors)
+        self.max_retry_after = max_retry_after
+        self.retry_policy = retry_policy or ExponentialBackoff(
+            factor=retry_backoff_factor,
+            max_backoff=DEFAULT_MAX_BACKOFF,
+            max_attempts=max_retries,
+            retry_transport_errors=retry_transport_errors,
+            max_retry_after=max_retry_after,
+        )
+        self.circuit_breaker = circuit_breaker
+        self.deprecations = DeprecationTracker()
+        self.stats_path = stats_path
+        self.middlewares: List[Middleware] = list(middlewares or [])
+        self.wire_dumper = WireDumper(wire_dump) if wire_dump is not None else None
 
         # Statistics
         self.request_count = 0
         self.error_count = 0
+        self.api_error_count = 0
+        self.transport_error_counts = dict.fromkeys(TRANSPORT_ERROR_CATEGORIES, 0)
+        self.failover_count = 0
+        self.callback_error_count = 0
+        self.circuit_rejection_count = 0
 
         # Create session with connection pooling
         self.session = requests.Session()
@@ -72,11 +293,14 @@ class BaseClient:
             "X-Goog-Api-Key": self.api_key,
             "User-Agent": "jules-agent-sdk/0.1.0 (Python)",
         })
+        self.session.headers.update(extra_headers or {})
 
         # Configure connection pool
-        adapter = requests.adapters.HTTPAdapter(
-            pool_connections=10,
-            pool_maxsize=20,
+        adapter = transport_adapter or TransportAdapter(
+            socket_options=socket_options,
+            pool_connections=pool_connections,
+            pool_maxsize=pool_maxsize,
+            pool_block=pool_block,
             max_retries=0,  # We handle retries manually
         )
         self.session.mount("http://", adapter)
@@ -84,47 +308,54 @@ class BaseClient:
 
         logger.info(f"Initialized Jules API client (base_url={self.base_url})")
 
-    def _should_retry(self, exception: Exception, attempt: int) -> bool:
-        """Determine if request should be retried.
-
-        Args:
-            exception: The exception that occurred
-            attempt: Current attempt number (1-indexed)
+    def _request_headers(self) -> Dict[str, str]:
+        """Build headers that vary per call on top of the session defaults.
 
         Returns:
-            True if should retry, False otherwise
+            Header overrides for the current call
         """
-        if attempt >= self.max_retries:
-            return False
-
-        # Retry on network errors
-        if isinstance(exception, (ConnectionError, Timeout)):
-            logger.warning(f"Network error on attempt {attempt}, will retry: {exception}")
-            return True
+        headers = current_headers()
+        if self.correlation_id_extractor is not None:
+            correlation_id = self.correlation_id_extractor()
+            if correlation_id:
+                headers[self.correlation_id_header] = correlation_id
+        api_key = current_api_key()
+        if api_key:
+            headers["X-Goog-Api-Key"] = api_key
+        return headers
+
+    def _request_timeout(self) -> Union[float, Tuple[float, float]]:
+        """Build the timeout passed to requests.
 
-        # Retry on 5xx errors
-        if isinstance(exception, JulesServerError):
-            logger.warning(f"Server error on attempt {attempt}, will retry: {exception}")
-            return True
-
-        # Don't retry on client errors (4xx)
-        return False
+        Returns:
+            The read timeout, or a (connect, read) tuple if connect_timeout is set
+        """
+        if self.connect_timeout is not None:
+            return (self.connect_timeout, self.timeout)
+        return self.timeout
 
-    def _calculate_backoff(self, attempt: int) -> float:
-        """Calculate backoff time for retry.
+    def _parse_json(self, response: requests.Response) -> Dict[str, Any]:
+        """Decode a response body as JSON.
 
         Args:
-            attempt: Current attempt number (1-indexed)
+            response: HTTP response object
 
         Returns:
-            Backoff time in seconds
+            Decoded response body
+
+        Raises:
+            UnexpectedContentTypeError: If the body is not valid JSON
         """
-        backoff = min(
-            self.retry_backoff_factor * (2 ** (attempt - 1)),
-            DEFAULT_MAX_BACKOFF,
-        )
-        logger.debug(f"Backoff for attempt {attempt}: {backoff}s")
-        return backoff
+        try:
+            if self.decode_options.use_decimal:
+                return response.json(parse_float=Decimal)
+            return response.json()
+        except ValueError as e:
+            content_type = response.headers.get("Content-Type", "")
+            logger.error(f"Failed to parse response as JSON ({content_type}): {e}")
+            raise UnexpectedContentTypeError.for_status(
+                response.status_code, content_type, response.text
+            ) from e
 
     def _handle_rate_limit(self, response: requests.Response) -> None:
         """Handle rate limit response.
@@ -136,18 +367,19 @@ class BaseClient:
             JulesRateLimitError: With retry information
         """
         retry_after = response.headers.get("Retry-After")
-        retry_info = {}
+        retry_info: Dict[str, Any] = {}
 
         if retry_after:
-            try:
-                retry_info["retry_after_seconds"] = int(retry_after)
-                logger.warning(f"Rate limited. Retry after {retry_after} seconds")
-            except ValueError:
+            seconds = parse_retry_after(retry_after)
+            if seconds is not None:
+                retry_info["retry_after_seconds"] = seconds
+                logger.warning(f"Rate limited. Retry after {seconds:g} seconds")
+            else:
                 logger.warning(f"Rate limited. Invalid Retry-After header: {retry_after}")
 
         error_msg = "Rate limit exceeded"
         if retry_info.get("retry_after_seconds"):
-            error_msg += f". Retry after {retry_info['retry_after_seconds']} seconds"
+            error_msg += f". Retry after {retry_info['retry_after_seconds']:g} seconds"
 
         raise JulesRateLimitError(error_msg, 429, retry_info)
 
@@ -163,6 +395,8 @@ class BaseClient:
             JulesValidationError: For 400 errors
             JulesRateLimitError: For 429 errors
             JulesServerError: For 5xx errors
+            UnexpectedContentTypeError: For error bodies that are not JSON, also an
+                instance of the status code's exception class
             JulesAPIError: For other errors
         """
         # Special handling for rate limits
@@ -171,11 +405,7 @@ class BaseClient:
             return
 
         # Parse error response
-        try:
-            error_data = response.json()
-        except (ValueError, json.JSONDecodeError) as e:
-            logger.debug(f"Failed to parse error response as JSON: {e}")
-            error_data = {"error": {"message": response.text}}
+        error_data = self._parse_json(response)
 
         error_msg = error_data.get("error", {}).get("message", response.text)
 
@@ -186,6 +416,7 @@ class BaseClient:
                 "status_code": response.status_code,
                 "url": response.url,
                 "response": error_data,
+                "request_id": response_request_id(response.headers),
             },
         )
 
@@ -220,75 +451,279 @@ class BaseClient:
             API response as dictionary
 
         Raises:
+            ReadOnlyModeError: If the client is read-only and method is not GET
             JulesAPIError: On API error
             Timeout: On timeout
             ConnectionError: On connection error
         """
-        url = f"{self.base_url}/{path.lstrip('/')}"
+        self._check_read_only(method, path)
         self.request_count += 1
+        note_request()
 
-        logger.debug(f"Request: {method} {path}", extra={"params": params, "json": json})
-
-        last_exception: Optional[Exception] = None
+        headers = self._request_headers()
+        correlation_id = headers.get(self.correlation_id_header)
+        logger.debug(
+            f"Request: {method} {path}",
+            extra={
+                "method": method,
+                "params": params,
+                "json": json,
+                "correlation_id": correlation_id,
+            },
+        )
 
-        for attempt in range(1, self.max_retries + 1):
+        failovers = 0
+        attempts = 0
+        while True:
+            url = f"{self.base_url}/{path.lstrip('/')}"
             try:
-                # Make request with timeout
-                response = self.session.request(
-                    method=method,
-                    url=url,
-                    params=params,
-                    json=json,
-                    timeout=self.timeout,
+                return self._request_with_retries(
+                    method, url, params=params, json=json, headers=headers
                 )
+            except JulesAPIError as e:
+                attempts += e.attempts or 0
+                if failovers < len(self.base_urls) - 1 and self._should_failover(e):
+                    failovers += 1
+                    self._failover(e)
+                    continue
 
-                logger.debug(
-                    f"Response: {response.status_code}",
-                    extra={"attempt": attempt, "status": response.status_code},
-                )
+                e.operation = f"{method} {path}"
+                e.resource = path.split(":", 1)[0]
+                e.attempts = attempts
+                e.correlation_id = correlation_id
+                raise
 
-                # Handle errors
-                if not response.ok:
-                    try:
-                        self._handle_error(response)
-                    except JulesAPIError as e:
-                        self.error_count += 1
-                        if self._should_retry(e, attempt):
-                            last_exception = e
-                            time.sleep(self._calculate_backoff(attempt))
-                            continue
-                        raise
-
-                # Handle empty responses
-                if response.status_code == 204 or not response.content:
-                    return {}
-
-                # Parse and return JSON
+    def _check_read_only(self, method: str, path: str) -> None:
+        """Reject mutating calls on a read-only client before anything is sent.
+
+        Args:
+            method: HTTP method
+            path: API endpoint path
+
+        Raises:
+            ReadOnlyModeError: If the client is read-only and method is not GET
+        """
+        if self.read_only and method != "GET":
+            error = ReadOnlyModeError(f"{method} {path} is not allowed on a read-only client")
+            error.operation = f"{method} {path}"
+            error.resource = path.split(":", 1)[0]
+            raise error
+
+    def _should_failover(self, exception: JulesAPIError) -> bool:
+        """Determine if an exhausted request should move to the next endpoint.
+
+        Args:
+            exception: The error that ended the retry loop
+
+        Returns:
+            True for server errors, network failures and open circuits,
+            False otherwise
+        """
+        if isinstance(exception, CircuitOpenError) or is_server_error(exception):
+            return True
+        return isinstance(exception.__cause__, (ConnectionError, Timeout))
+
+    def _failover(self, exception: Exception) -> None:
+        """Switch to the next configured base URL.
+
+        Args:
+            exception: The error that triggered the failover
+        """
+        index = self.base_urls.index(self.base_url)
+        previous = self.base_url
+        self.base_url = self.base_urls[(index + 1) % len(self.base_urls)]
+        self.failover_count += 1
+
+        logger.warning(f"Failing over from {previous} to {self.base_url}: {exception}")
+        self._notify(self.on_failover, previous, self.base_url, exception)
+
+    def use(self, middleware: Middleware) -> None:
+        """Add a middleware around every HTTP attempt.
+
+        A middleware receives the next Doer and returns a Doer, so it can
+        rewrite the method, URL, headers or body before calling next and
+        inspect or replace the response afterwards. Middlewares run in the
+        order they were added, the first one outermost, and see each retry
+        and failover attempt separately.
+
+        Args:
+            middleware: Callable taking the next Doer and returning a Doer
+
+        Example:
+            >>> def timing(next_doer):
+            ...     def doer(method, url, **kwargs):
+            ...         start = time.monotonic()
+            ...         response = next_doer(method, url, **kwargs)
+            ...         print(method, url, response.status_code, time.monotonic() - start)
+            ...         return response
+            ...     return doer
+            >>> client._base_client.use(timing)
+        """
+        self.middlewares.append(middleware)
+
+    def _doer(self) -> Doer:
+        """Build the middleware chain around the session's request method."""
+        doer: Doer = self.session.request
+        if self.wire_dumper is not None:
+            # Innermost, so the dump shows what the other middlewares sent
+            doer = self.wire_dumper.middleware(doer)
+        for middleware in reversed(self.middlewares):
+            doer = middleware(doer)
+        return doer
+
+    def _notify(self, callback: Optional[Callable[..., Any]], *args: Any) -> None:
+        """Invoke a notification callback, counting failures instead of raising.
+
+        Args:
+            callback: Callback to invoke; None is a no-op
+            *args: Arguments passed to the callback
+        """
+        if not invoke_callback(callback, *args, propagate=self.raise_callback_errors):
+            self.callback_error_count += 1
+
+    def _request_with_retries(
+        self,
+        method: str,
+        url: str,
+        params: Optional[Dict[str, Any]] = None,
+        json: Optional[Dict[str, Any]] = None,
+        headers: Optional[Dict[str, str]] = None,
+    ) -> Dict[str, Any]:
+        """Make an HTTP request against a single endpoint with retries.
+
+        Args:
+            method: HTTP method (GET, POST, etc.)
+            url: Fully qualified request URL
+            params: Query parameters
+            json: JSON request body
+            headers: Per-call headers sent with every attempt
+
+        Returns:
+            API response as dictionary
+
+        Raises:
+            JulesAPIError: On API error or once retries are exhausted
+        """
+        last_exception: Optional[Exception] = None
+        attempt = 0
+        delay = 0.0
+        policy = self.retry_policy
+        doer = self._doer()
+
+        try:
+            for attempt in range(1, policy.max_attempts + 1):
+                self._check_circuit(attempt, last_exception)
                 try:
-                    return response.json()
-                except (ValueError, json.JSONDecodeError) as e:
-                    logger.error(f"Failed to parse response as JSON: {e}")
-                    raise JulesAPIError(f"Invalid JSON response: {e}")
-
-            except (ConnectionError, Timeout) as e:
-                self.error_count += 1
-                logger.warning(f"Request failed (attempt {attempt}/{self.max_retries}): {e}")
-
-                if self._should_retry(e, attempt):
-                    last_exception = e
-                    time.sleep(self._calculate_backoff(attempt))
-                    continue
+                    # Make request with timeout
+                    with attempt_scope(attempt):
+                        response = doer(
+                            method=method,
+                            url=url,
+                            params=params,
+                            json=json,
+                            headers=headers,
+                            timeout=self._request_timeout(),
+                        )
+
+                    endpoint = f"{method} {route(urlsplit(url).path)}"
+                    self.deprecations.observe(endpoint, response.headers)
+                    self._record_circuit(response.status_code < 500)
+                    request_id = response_request_id(response.headers)
+
+                    logger.debug(
+                        f"Response: {response.status_code}",
+                        extra={
+                            "method": method,
+                            "attempt": attempt,
+                            "status": response.status_code,
+                            "correlation_id": (headers or {}).get(self.correlation_id_header),
+                            "request_id": request_id,
+                        },
+                    )
+
+                    # Handle errors
+                    if not response.ok:
+                        try:
+                            self._handle_error(response)
+                        except JulesAPIError as e:
+                            e.request_id = request_id
+                            self.error_count += 1
+                            self.api_error_count += 1
+                            self._release(response)
+                            if policy.should_retry(method, e, attempt):
+                                last_exception = e
+                                delay = policy.next_delay(e, attempt, delay)
+                                time.sleep(delay)
+                                continue
+                            raise
+
+                    # Handle empty responses
+                    if response.status_code == 204 or not response.content:
+                        return {}
+
+                    # Parse and return JSON
+                    return self._parse_json(response)
+
+                except (ConnectionError, Timeout) as e:
+                    self.error_count += 1
+                    self.transport_error_counts[classify_transport_error(e)] += 1
+                    self._record_circuit(False)
+                    logger.warning(
+                        f"Request failed (attempt {attempt}/{policy.max_attempts}): {e}"
+                    )
+
+                    if policy.should_retry(method, e, attempt):
+                        last_exception = e
+                        delay = policy.next_delay(e, attempt, delay)
+                        time.sleep(delay)
+                        continue
+
+                    raise JulesAPIError(f"Request failed after {attempt} attempts: {e}") from e
+
+            # If we got here, all retries were exhausted
+            if last_exception:
+                raise JulesAPIError(
+                    f"Request failed after {policy.max_attempts} retries: {last_exception}"
+                ) from last_exception
+
+            # Shouldn't reach here, but just in case
+            raise JulesAPIError("Request failed for unknown reason")
+        except JulesAPIError as e:
+            if e.attempts is None:
+                e.attempts = attempt
+            raise
+
+    def _check_circuit(self, attempt: int, last_exception: Optional[Exception]) -> None:
+        """Fail fast instead of making an attempt if the circuit is open.
+
+        Args:
+            attempt: Attempt about to be made (1-indexed)
+            last_exception: Error of the previous attempt, if any
 
-                raise JulesAPIError(f"Request failed after {attempt} attempts: {e}") from e
+        Raises:
+            CircuitOpenError: If the breaker rejects the attempt
+        """
+        if self.circuit_breaker is None:
+            return
+        try:
+            self.circuit_breaker.before_request(self.base_url)
+        except CircuitOpenError as e:
+            self.circuit_rejection_count += 1
+            e.attempts = attempt - 1
+            raise e from last_exception
 
-        # If we got here, all retries were exhausted
-        if last_exception:
-            raise JulesAPIError(
-                f"Request failed after {self.max_retries} retries: {last_exception}"
-            ) from last_exception
+    def _record_circuit(self, healthy: bool) -> None:
+        """Report the outcome of an attempt to the circuit breaker, if any.
 
-        # Shouldn't reach here, but just in case
-        raise JulesAPIError("Request failed for unknown reason")
+        Args:
+            healthy: False for 5xx responses and network failures
+        """
+        if self.circuit_breaker is None:
+            return
+        if healthy:
+            self.circuit_breaker.record_success(self.base_url)
+        else:
+            self.circuit_breaker.record_failure(self.base_url)
 
     def get(self, path: str, params: Optional[Dict[str, Any]] = None) -> Dict[str, Any]:
         """Make a GET request.
@@ -320,22 +755,96 @@ class BaseClient:
         """
         return self._request("POST", path, params=params, json=json)
 
+    @staticmethod
+    def _release(response: requests.Response) -> None:
+        """Drain and close a response so its connection goes back to the pool.
+
+        Closing a response with unread body discards the connection instead of
+        reusing it, so error responses are read to the end first.
+        """
+        try:
+            response.content  # reading the body to the end lets urllib3 reuse the connection
+        except RequestException as e:
+            logger.debug(f"Could not drain response body: {e}")
+        finally:
+            response.close()
+
+    def _pool_stats(self) -> Dict[str, int]:
+        """Sum connection counters over the urllib3 pools of the mounted adapters."""
+        opened = sent = 0
+        for adapter in set(self.session.adapters.values()):
+            pools = getattr(getattr(adapter, "poolmanager", None), "pools", None)
+            if pools is None:
+                continue
+            for key in pools.keys():
+                pool = pools.get(key)
+                opened += getattr(pool, "num_connections", 0)
+                sent += getattr(pool, "num_requests", 0)
+        return {
+            "connections_opened": opened,
+            "connections_reused": max(sent - opened, 0),
+        }
+
     def get_stats(self) -> Dict[str, int]:
         """Get client usage statistics.
 
+        errors is split into api_errors, for error responses from the service,
+        and one transport_<category> count per TRANSPORT_ERROR_CATEGORIES entry
+        for network-side failures such as transport_timeout or transport_dns.
+
+        connections_opened and connections_reused come from the connection
+        pools, so a reused count near zero under load means pooling is not
+        working, e.g. because pool_maxsize is too small for the concurrency.
+
         Returns:
-            Dictionary with request and error counts
+            Dictionary with request, error, failover, callback error and circuit
+            rejection counts, per-category error counts, plus connections opened and reused
         """
         return {
             "requests": self.request_count,
             "errors": self.error_count,
+            "api_errors": self.api_error_count,
+            **{f"transport_{k}": v for k, v in self.transport_error_counts.items()},
+            "failovers": self.failover_count,
+            "callback_errors": self.callback_error_count,
+            "circuit_rejections": self.circuit_rejection_count,
+            **self._pool_stats(),
         }
 
+    def snapshot_stats(self) -> Dict[str, Any]:
+        """Get the current statistics together with when and where they were taken.
+
+        Returns:
+            The get_stats() counters plus "time" (UTC, ISO 8601) and "base_url"
+        """
+        return {
+            "time": datetime.now(timezone.utc).isoformat(),
+            "base_url": self.base_url,
+            **self.get_stats(),
+        }
+
+    def write_stats(self, fp: TextIO) -> None:
+        """Write a stats snapshot to a file as one line of JSON.
+
+        Appending a line per run builds a JSONL history that can be summed
+        later without running a metrics server.
+
+        Args:
+            fp: Text file opened for writing or appending
+        """
+        fp.write(jsonlib.dumps(self.snapshot_stats(), sort_keys=True) + "\n")
+
     def close(self) -> None:
-        """Close the HTTP session."""
+        """Close the HTTP session, writing a stats snapshot if stats_path is set."""
         logger.info(
             f"Closing client. Stats: {self.request_count} requests, {self.error_count} errors"
         )
+        if self.stats_path:
+            try:
+                with open(self.stats_path, "a", encoding="utf-8") as fp:
+                    self.write_stats(fp)
+            except OSError as e:
+                logger.warning(f"Could not write stats to {self.stats_path}: {e}")
         self.session.close()
 
     def __enter__(self) -> "BaseClient":
@@ -0,0 +1,71 @@
+"""Safe invocation of user-supplied notification callbacks."""
+
+import inspect
+import logging
+from typing import Any, Callable, Optional
+
+logger = logging.getLogger(__name__)
+
+
+def invoke_callback(
+    callback: Optional[Callable[..., Any]], *args: Any, propagate: bool = False
+) -> bool:
+    """Invoke a notification callback, isolating the caller from its failures.
+
+    Notification callbacks (failover events, state changes, ...) run inside
+    request and polling loops. An exception from a slow or broken notifier is
+    logged and swallowed so the loop keeps running, unless propagate is set.
+
+    Policy hooks whose errors are meaningful, such as create interceptors and
+    feedback handlers, are not invoked through this helper.
+
+    Args:
+        callback: Callable to invoke; None is a no-op
+        *args: Arguments passed to the callback
+        propagate: Re-raise exceptions instead of logging them
+
+    Returns:
+        True if the callback ran without raising, False otherwise
+    """
+    if callback is None:
+        return True
+
+    try:
+        callback(*args)
+    except Exception:
+        if propagate:
+            raise
+        logger.exception(f"Callback {callback!r} raised an exception; continuing")
+        return False
+    return True
+
+
+async def invoke_async_callback(
+    callback: Optional[Callable[..., Any]], *args: Any, propagate: bool = False
+) -> bool:
+    """Invoke a notification callback from async code, awaiting its result if needed.
+
+    Same isolation as invoke_callback(), for callbacks that may be plain
+    functions or coroutine functions.
+
+    Args:
+        callback: Callable to invoke; None is a no-op
+        *args: Arguments passed to the callback
+        propagate: Re-raise exceptions instead of logging them
+
+    Returns:
+        True if the callback ran without raising, False otherwise
+    """
+    if callback is None:
+        return True
+
+    try:
+        result = callback(*args)
+        if inspect.isawaitable(result):
+            await result
+    except Exception:
+        if propagate:
+            raise
+        logger.exception(f"Callback {callback!r} raised an exception; continuing")
+        return False
+    return True
@@ -0,0 +1,59 @@
+"""Cancellation events that record why a wait was cancelled."""
+
+import asyncio
+import threading
+from enum import Enum
+from typing import Optional, Union
+
+
+class CancelReason(str, Enum):
+    """Common reasons for cancelling a wait; any string is accepted as well."""
+
+    USER = "USER"
+    POLICY = "POLICY"
+    TIMEOUT = "TIMEOUT"
+    SHUTDOWN = "SHUTDOWN"
+
+    def __str__(self) -> str:
+        """Return the bare reason, e.g. ``POLICY``."""
+        return self.value
+
+
+class CancelEvent(threading.Event):
+    """A threading.Event that carries the reason it was set.
+
+    Pass it as cancel_event to wait_for_completion; the resulting
+    WaitCancelledError has the reason attached, so post-mortems can tell
+    user aborts from policy rejections and deadlines.
+
+    Example:
+        >>> cancel = CancelEvent()
+        >>> signal.signal(signal.SIGTERM, lambda *_: cancel.cancel(CancelReason.SHUTDOWN))
+        >>> client.sessions.wait_for_completion("abc123", cancel_event=cancel)
+    """
+
+    def __init__(self) -> None:
+        """Initialize an unset event."""
+        super().__init__()
+        self.reason: Optional[str] = None
+
+    def cancel(self, reason: Union[CancelReason, str] = CancelReason.USER) -> None:
+        """Record the reason and set the event; the first reason wins."""
+        if not self.is_set():
+            self.reason = str(reason)
+        self.set()
+
+
+class AsyncCancelEvent(asyncio.Event):
+    """asyncio.Event counterpart of CancelEvent."""
+
+    def __init__(self) -> None:
+        """Initialize an unset event."""
+        super().__init__()
+        self.reason: Optional[str] = None
+
+    def cancel(self, reason: Union[CancelReason, str] = CancelReason.USER) -> None:
+        """Record the reason and set the event; the first reason wins."""
+        if not self.is_set():
+            self.reason = str(reason)
+        self.set()
@@ -0,0 +1,125 @@
+"""Circuit breaker that stops the sync client hammering a failing endpoint.
+
+After failure_threshold consecutive 5xx responses or network failures against
+a base URL, the circuit for it opens and requests fail fast with
+CircuitOpenError instead of being sent. Once the cool-down has passed, one
+trial request is let through: success closes the circuit, failure opens it
+for another cool-down. With fallback_base_urls, an open circuit fails over to
+the next endpoint.
+"""
+
+import logging
+import threading
+import time
+from dataclasses import dataclass
+from typing import Callable, Dict, Optional
+
+from jules_agent_sdk.exceptions import CircuitOpenError
+
+logger = logging.getLogger(__name__)
+
+DEFAULT_FAILURE_THRESHOLD = 5
+DEFAULT_COOLDOWN = 30.0
+
+# Circuit states, see CircuitBreaker.state
+CLOSED = "closed"
+OPEN = "open"
+HALF_OPEN = "half_open"
+
+
+@dataclass
+class _Circuit:
+    """State of the circuit for one endpoint."""
+
+    failures: int = 0
+    opened_at: Optional[float] = None
+    trial_in_flight: bool = False
+
+
+class CircuitBreaker:
+    """Fails fast for endpoints that keep failing, per base URL.
+
+    One breaker can be shared by several clients, so all of a process's
+    workers back off from a degraded endpoint together.
+
+    Example:
+        >>> breaker = CircuitBreaker(failure_threshold=5, cooldown=30)
+        >>> client = JulesClient(api_key=key, circuit_breaker=breaker)
+    """
+
+    def __init__(
+        self,
+        failure_threshold: int = DEFAULT_FAILURE_THRESHOLD,
+        cooldown: float = DEFAULT_COOLDOWN,
+        clock: Callable[[], float] = time.monotonic,
+    ) -> None:
+        """Initialize the breaker.
+
+        Args:
+            failure_threshold: Consecutive failures that open the circuit
+            cooldown: Seconds the circuit stays open before a trial request
+            clock: Monotonic time source, replaceable in tests
+        """
+        if failure_threshold < 1:
+            raise ValueError("failure_threshold must be at least 1")
+        self.failure_threshold = failure_threshold
+        self.cooldown = cooldown
+        self.clock = clock
+        self._circuits: Dict[str, _Circuit] = {}
+        self._lock = threading.Lock()
+
+    def before_request(self, endpoint: str) -> None:
+        """Let a request to endpoint through, or fail fast if its circuit is open.
+
+        Args:
+            endpoint: Base URL the request goes to
+
+        Raises:
+            CircuitOpenError: If the circuit is open, or half-open with a trial
+                request already sent within the cool-down
+        """
+        with self._lock:
+            circuit = self._circuits.get(endpoint)
+            if circuit is None or circuit.opened_at is None:
+                return
+            now = self.clock()
+            remaining = circuit.opened_at + self.cooldown - now
+            if remaining > 0:
+                raise CircuitOpenError(endpoint, remaining)
+            # Restart the cool-down, so a trial that never reports back is
+            # followed by another one rather than blocking the endpoint for good
+            circuit.opened_at = now
+            circuit.trial_in_flight = True
+        logger.info(f"Circuit half-open for {endpoint}; sending a trial request")
+
+    def record_success(self, endpoint: str) -> None:
+        """Record a response that shows endpoint is healthy, closing its circuit."""
+        with self._lock:
+            circuit = self._circuits.pop(endpoint, None)
+        if circuit is not None and circuit.opened_at is not None:
+            logger.info(f"Circuit closed for {endpoint}")
+
+    def record_failure(self, endpoint: str) -> None:
+        """Record a 5xx response or network failure from endpoint."""
+        with self._lock:
+            circuit = self._circuits.setdefault(endpoint, _Circuit())
+            circuit.failures += 1
+            tripped = circuit.opened_at is None and circuit.failures >= self.failure_threshold
+            if not (tripped or circuit.trial_in_flight):
+                return
+            circuit.opened_at = self.clock()
+            circuit.trial_in_flight = False
+        logger.warning(
+            f"Circuit open for {endpoint} after {circuit.failures} consecutive failures; "
+            f"failing fast for {self.cooldown:g}s"
+        )
+
+    def state(self, endpoint: str) -> str:
+        """Return CLOSED, OPEN or HALF_OPEN for endpoint's circuit."""
+        with self._lock:
+            circuit = self._circuits.get(endpoint)
+            if circuit is None or circuit.opened_at is None:
+                return CLOSED
+            if circuit.trial_in_flight or self.clock() >= circuit.opened_at + self.cooldown:
+                return HALF_OPEN
+            return OPEN
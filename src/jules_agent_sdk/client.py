@@ -1,10 +1,31 @@
 """Main Jules API client."""
 
-from typing import Optional
-from jules_agent_sdk.base import BaseClient
-from jules_agent_sdk.sessions import SessionsAPI
+from typing import Any, Dict, Iterable, Optional, List, TextIO
+from requests.adapters import HTTPAdapter
+from jules_agent_sdk.base import (
+    DEFAULT_MAX_RETRY_AFTER,
+    TRANSPORT_ERROR_CATEGORIES,
+    BaseClient,
+    CorrelationIdExtractor,
+    FailoverHandler,
+    Middleware,
+    SocketOption,
+)
+from jules_agent_sdk.circuit import CircuitBreaker
+from jules_agent_sdk.decoding import DecodeOptions
+from jules_agent_sdk.deprecation import DeprecationNotice
+from jules_agent_sdk.prompt import PromptProcessor
+from jules_agent_sdk.context import DEFAULT_CORRELATION_ID_HEADER, current_correlation_id
+from jules_agent_sdk.sessions import (
+    DEFAULT_IDEMPOTENCY_KEY_HEADER,
+    CreateInterceptor,
+    SessionsAPI,
+)
 from jules_agent_sdk.activities import ActivitiesAPI
 from jules_agent_sdk.sources import SourcesAPI
+from jules_agent_sdk.handles import SessionHandle, SourceHandle
+from jules_agent_sdk.resources import session_path
+from jules_agent_sdk.retry import RetryPolicy
 
 
 class JulesClient:
@@ -52,6 +73,36 @@ class JulesClient:
         timeout: int = 30,
         max_retries: int = 3,
         retry_backoff_factor: float = 1.0,
+        default_require_plan_approval: bool = False,
+        create_interceptors: Optional[List[CreateInterceptor]] = None,
+        max_prompt_tokens: Optional[int] = None,
+        fallback_base_urls: Optional[List[str]] = None,
+        on_failover: Optional[FailoverHandler] = None,
+        connect_timeout: Optional[float] = None,
+        socket_options: Optional[List[SocketOption]] = None,
+        transport_adapter: Optional[HTTPAdapter] = None,
+        pool_connections: int = 10,
+        pool_maxsize: int = 20,
+        pool_block: bool = False,
+        raise_callback_errors: bool = False,
+        default_page_size: Optional[int] = None,
+        strict_ids: bool = False,
+        extra_headers: Optional[Dict[str, str]] = None,
+        correlation_id_extractor: Optional[CorrelationIdExtractor] = current_correlation_id,
+        correlation_id_header: str = DEFAULT_CORRELATION_ID_HEADER,
+        max_creates_per_minute: Optional[int] = None,
+        read_only: bool = False,
+        decode_options: Optional[DecodeOptions] = None,
+        prompt_processors: Optional[List[PromptProcessor]] = None,
+        retry_transport_errors: Iterable[str] = TRANSPORT_ERROR_CATEGORIES,
+        stats_path: Optional[str] = None,
+        middlewares: Optional[List[Middleware]] = None,
+        wire_dump: Optional[TextIO] = None,
+        pin_default_branch: bool = False,
+        max_retry_after: float = DEFAULT_MAX_RETRY_AFTER,
+        retry_policy: Optional[RetryPolicy] = None,
+        circuit_breaker: Optional[CircuitBreaker] = None,
+        idempotency_key_header: Optional[str] = DEFAULT_IDEMPOTENCY_KEY_HEADER,
     ) -> None:
         """Initialize the Jules API client.
 
@@ -61,6 +112,81 @@ class JulesClient:
             timeout: Request timeout in seconds (default: 30)
             max_retries: Maximum number of retry attempts (default: 3)
             retry_backoff_factor: Backoff factor for retries (default: 1.0)
+            default_require_plan_approval: Require plan approval for every session
+                created through this client unless overridden per call (default: False)
+            create_interceptors: Callables applied to every create request body;
+                each may mutate the body or raise to reject the request
+            max_prompt_tokens: Optional estimated token limit checked locally before
+                prompts and messages are sent (default: no check)
+            fallback_base_urls: Alternate base URLs to fail over to when the current
+                endpoint keeps returning 5xx or network errors
+            on_failover: Callback invoked with (old_url, new_url, error) on failover
+            connect_timeout: Separate connect/TLS handshake timeout in seconds
+                (default: same as timeout)
+            socket_options: Socket options for new connections, e.g.
+                jules_agent_sdk.base.TCP_KEEPALIVE_SOCKET_OPTIONS
+            transport_adapter: Custom requests HTTPAdapter for DNS, proxy or
+                dialer customization
+            pool_connections: Number of per-host connection pools to cache (default: 10)
+            pool_maxsize: Maximum connections kept open per host (default: 20). Raise
+                this for orchestrators polling many sessions concurrently
+            pool_block: Block instead of opening connections beyond pool_maxsize
+                (default: False)
+            raise_callback_errors: Propagate exceptions raised by notification
+                callbacks instead of logging them (default: False)
+            default_page_size: Page size for list calls that do not specify one
+                (default: server default, capped at 100)
+            strict_ids: Reject URLs, whitespace and other ambiguous IDs instead of
+                normalizing them (default: False)
+            extra_headers: Headers added to every request, for API gateways that
+                require auth tokens, correlation IDs or cost-center tags. Use
+                jules_agent_sdk.use_headers() for per-call headers
+            correlation_id_extractor: Called once per request to get a trace or
+                correlation ID, which is sent as a header and attached to logs and
+                errors (default: the ID set with use_correlation_id(); None disables)
+            correlation_id_header: Header carrying the correlation ID
+                (default: "X-Correlation-ID")
+            max_creates_per_minute: Limit on sessions created per minute by this
+                client, for fan-outs; calls over the limit wait locally rather than
+                fail (default: no limit)
+            read_only: Block every call that could create, message or approve
+                anything with ReadOnlyModeError, for dashboards and analytics
+                (default: False)
+            decode_options: Response decoding strictness: Decimal numbers, RFC 3339
+                timestamp checks and unknown-field rejection (default: lenient)
+            prompt_processors: Callables applied, in order, to every prompt and
+                message before it is sent, e.g. prompt.normalize_whitespace or
+                prompt.scrub_emails
+            retry_transport_errors: Network failure categories that are retried,
+                from jules_agent_sdk.base.TRANSPORT_ERROR_CATEGORIES; e.g. leave
+                out TRANSPORT_TIMEOUT to never resend a request that timed out
+                (default: all)
+            stats_path: File to append a JSON line of client statistics to when
+                the client is closed, for usage history across short-lived runs
+                (default: none)
+            middlewares: Callables wrapping every HTTP attempt for logging, metrics,
+                auth rewriting or request mutation; see use()
+            wire_dump: Text stream, e.g. sys.stderr, that every HTTP request and
+                response is written to in full with credentials redacted, for
+                diagnosing rejected requests (default: none)
+            pin_default_branch: When a session is created without a starting
+                branch, send the source's current default branch, so the session
+                records the exact base it started from (default: False)
+            max_retry_after: Longest Retry-After delay in seconds the client waits
+                out itself when rate limited (429); longer delays raise
+                JulesRateLimitError right away, and 0 disables the retry
+                (default: 60)
+            retry_policy: Custom RetryPolicy from jules_agent_sdk.retry, e.g.
+                DecorrelatedJitter() or FixedDelay(no_retry_methods=["POST"]);
+                overrides the four retry options above (default: exponential
+                backoff built from them)
+            circuit_breaker: CircuitBreaker that stops sending requests to an
+                endpoint after repeated 5xx or network failures and raises
+                CircuitOpenError until its cool-down has passed; share one
+                between clients to back off together (default: none)
+            idempotency_key_header: Header that create() sends its idempotency key
+                in, so a create retried after a network error does not start a
+                duplicate session; None sends no key (default: "Idempotency-Key")
 
         Raises:
             ValueError: If api_key is empty or None
@@ -74,10 +200,117 @@ class JulesClient:
             timeout=timeout,
             max_retries=max_retries,
             retry_backoff_factor=retry_backoff_factor,
+            fallback_base_urls=fallback_base_urls,
+            on_failover=on_failover,
+            connect_timeout=connect_timeout,
+            socket_options=socket_options,
+            transport_adapter=transport_adapter,
+            pool_connections=pool_connections,
+            pool_maxsize=pool_maxsize,
+            pool_block=pool_block,
+            raise_callback_errors=raise_callback_errors,
+            extra_headers=extra_headers,
+            correlation_id_extractor=correlation_id_extractor,
+            correlation_id_header=correlation_id_header,
+            read_only=read_only,
+            decode_options=decode_options,
+            retry_transport_errors=retry_transport_errors,
+            stats_path=stats_path,
+            middlewares=middlewares,
+            wire_dump=wire_dump,
+            max_retry_after=max_retry_after,
+            retry_policy=retry_policy,
+            circuit_breaker=circuit_breaker,
         )
-        self.sessions = SessionsAPI(self._base_client)
-        self.activities = ActivitiesAPI(self._base_client)
-        self.sources = SourcesAPI(self._base_client)
+        self.sessions = SessionsAPI(
+            self._base_client,
+            default_require_plan_approval=default_require_plan_approval,
+            create_interceptors=create_interceptors,
+            max_prompt_tokens=max_prompt_tokens,
+            default_page_size=default_page_size,
+            strict_ids=strict_ids,
+            max_creates_per_minute=max_creates_per_minute,
+            prompt_processors=prompt_processors,
+            pin_default_branch=pin_default_branch,
+            idempotency_key_header=idempotency_key_header,
+        )
+        self.activities = ActivitiesAPI(self._base_client, default_page_size, strict_ids)
+        self.sources = SourcesAPI(self._base_client, default_page_size, strict_ids)
+
+    def session(
+        self, session_id: str, log_fields: Optional[Dict[str, Any]] = None
+    ) -> SessionHandle:
+        """Get a handle bound to one session.
+
+        Args:
+            session_id: The session ID, full name or (unless strict_ids) URL
+            log_fields: Extra fields attached to the handle's log records
+
+        Returns:
+            SessionHandle for the session
+
+        Raises:
+            InvalidResourceNameError: If session_id is malformed
+
+        Example:
+            >>> handle = client.session("abc123", log_fields={"ticket": "OPS-42"})
+            >>> handle.approve_plan()
+            >>> final = handle.wait()
+        """
+        name = session_path(session_id, self.sessions.strict_ids)
+        return SessionHandle(self.sessions, self.activities, name, log_fields)
+
+    def source(self, name_or_repo: str) -> SourceHandle:
+        """Get a handle bound to one source.
+
+        Resolves the source immediately, so this makes at least one request.
+
+        Args:
+            name_or_repo: Source name, source ID or GitHub "owner/repo"
+
+        Returns:
+            SourceHandle for the source
+
+        Raises:
+            JulesNotFoundError: If no accessible source matches
+
+        Example:
+            >>> repo = client.source("octo/app")
+            >>> session = repo.create_session("Upgrade dependencies")
+        """
+        return SourceHandle(self.sources, self.sessions, self.sources.resolve(name_or_repo))
+
+    def use(self, middleware: Middleware) -> None:
+        """Add a middleware around every HTTP request of every API.
+
+        Args:
+            middleware: Callable taking the next Doer and returning a Doer; see
+                BaseClient.use
+
+        Example:
+            >>> def add_tenant(next_doer):
+            ...     def doer(method, url, **kwargs):
+            ...         kwargs["headers"] = {**(kwargs.get("headers") or {}), "X-Tenant": "core"}
+            ...         return next_doer(method, url, **kwargs)
+            ...     return doer
+            >>> client.use(add_tenant)
+        """
+        self._base_client.use(middleware)
+
+    def deprecation_notices(self) -> List[DeprecationNotice]:
+        """Get the endpoints the server has announced as deprecated.
+
+        Responses with Deprecation or Sunset headers are recorded, and logged
+        as a warning, once per endpoint.
+
+        Returns:
+            Notices in the order they were first seen
+
+        Example:
+            >>> for notice in client.deprecation_notices():
+            ...     print(notice.endpoint, notice.sunset_at, notice.link)
+        """
+        return self._base_client.deprecations.notices()
 
     def close(self) -> None:
         """Close the HTTP session.
@@ -0,0 +1,153 @@
+"""Side-by-side comparison of two sessions, e.g. runs of one task with different prompts."""
+
+from dataclasses import dataclass
+from typing import TYPE_CHECKING, Any, List, Optional, Set, Tuple
+
+from jules_agent_sdk.artifacts import ArtifactIndex
+from jules_agent_sdk.models import (
+    Activity,
+    CompletionDetails,
+    PlanProgress,
+    Session,
+    parse_timestamp,
+)
+
+if TYPE_CHECKING:
+    from jules_agent_sdk.async_client import AsyncJulesClient
+    from jules_agent_sdk.client import JulesClient
+
+
+@dataclass
+class SessionSummary:
+    """The comparable facts about one session."""
+
+    session: Session
+    plan_steps: int = 0
+    files_changed: int = 0
+    lines_added: int = 0
+    lines_removed: int = 0
+    activity_count: int = 0
+    duration: Optional[float] = None
+
+    @classmethod
+    def from_session(cls, session: Session, activities: List[Activity]) -> "SessionSummary":
+        """Summarize a session from its activities.
+
+        Diff stats come from the final change sets of the sessionCompleted
+        activity, or from the latest change set for unfinished sessions.
+
+        Args:
+            session: The session
+            activities: All activities of the session
+
+        Returns:
+            SessionSummary
+        """
+        progress = PlanProgress.from_activities(activities)
+        change_sets = CompletionDetails.from_session(session, activities).change_sets
+        if not change_sets:
+            latest = ArtifactIndex.from_activities(activities).latest_change_set
+            change_sets = [latest] if latest else []
+
+        files: Set[str] = set()
+        added = removed = 0
+        for change_set in change_sets:
+            if change_set.git_patch is None:
+                continue
+            for hunk in change_set.git_patch.hunks():
+                # Deleted files all have /dev/null as their new path
+                deleted = hunk.new_path in ("", "/dev/null")
+                files.add(hunk.old_path if deleted else hunk.new_path)
+                added += hunk.added
+                removed += hunk.removed
+
+        start, end = parse_timestamp(session.create_time), parse_timestamp(session.update_time)
+        return cls(
+            session=session,
+            plan_steps=progress.total_steps if progress else 0,
+            files_changed=len(files),
+            lines_added=added,
+            lines_removed=removed,
+            activity_count=len(activities),
+            duration=(end - start).total_seconds() if start and end else None,
+        )
+
+
+@dataclass
+class SessionComparison:
+    """Two sessions side by side.
+
+    Example:
+        >>> comparison = compare_sessions(client, "123", "456")
+        >>> print(comparison)
+        >>> if comparison.same_prompt:
+        ...     print("Only the run differs, not the prompt")
+    """
+
+    a: SessionSummary
+    b: SessionSummary
+
+    @property
+    def same_prompt(self) -> bool:
+        """Whether both sessions were started with the same prompt."""
+        return self.a.session.prompt.strip() == self.b.session.prompt.strip()
+
+    def rows(self) -> List[Tuple[str, Any, Any]]:
+        """Return (field, a, b) rows, e.g. for rendering a table."""
+        return [
+            ("state", str(self.a.session.state), str(self.b.session.state)),
+            ("plan steps", self.a.plan_steps, self.b.plan_steps),
+            ("files changed", self.a.files_changed, self.b.files_changed),
+            ("lines added", self.a.lines_added, self.b.lines_added),
+            ("lines removed", self.a.lines_removed, self.b.lines_removed),
+            ("activities", self.a.activity_count, self.b.activity_count),
+            ("duration (s)", self.a.duration, self.b.duration),
+            ("pull request", _pull_request_url(self.a), _pull_request_url(self.b)),
+        ]
+
+    def __str__(self) -> str:
+        """Render the comparison as a plain-text table."""
+        table = [["", self.a.session.name, self.b.session.name]]
+        for name, a, b in self.rows():
+            table.append([name, "" if a is None else str(a), "" if b is None else str(b)])
+
+        widths = [max(len(row[i]) for row in table) for i in range(3)]
+        lines = ["  ".join(c.ljust(w) for c, w in zip(row, widths)).rstrip() for row in table]
+        return "\n".join(lines)
+
+
+def _pull_request_url(summary: SessionSummary) -> str:
+    """URL of the session's pull request, or "" if it has none."""
+    pull_request = summary.session.pull_request
+    return pull_request.url if pull_request else ""
+
+
+def compare_sessions(client: "JulesClient", session_a: str, session_b: str) -> SessionComparison:
+    """Fetch two sessions with their activities and compare them.
+
+    Args:
+        client: Client to fetch with
+        session_a: First session ID or name
+        session_b: Second session ID or name
+
+    Returns:
+        SessionComparison
+    """
+    summaries = [
+        SessionSummary.from_session(client.sessions.get(s), client.activities.list_all(s))
+        for s in (session_a, session_b)
+    ]
+    return SessionComparison(*summaries)
+
+
+async def compare_sessions_async(
+    client: "AsyncJulesClient", session_a: str, session_b: str
+) -> SessionComparison:
+    """Fetch two sessions with their activities asynchronously and compare them."""
+    summaries = [
+        SessionSummary.from_session(
+            await client.sessions.get(s), await client.activities.list_all(s)
+        )
+        for s in (session_a, session_b)
+    ]
+    return SessionComparison(*summaries)
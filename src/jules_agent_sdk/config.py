@@ -1,7 +1,21 @@
 """Configuration management for Jules Agent SDK."""
 
-from dataclasses import dataclass
-from typing import Optional
+from dataclasses import asdict, dataclass, field, replace
+from typing import Any, Dict, Optional
+
+from jules_agent_sdk.wiredump import REDACTED, REDACTED_HEADERS
+
+
+def mask_secret(secret: str) -> str:
+    """Mask a secret for display, keeping the last 4 characters of long ones.
+
+    Example:
+        >>> mask_secret("AIzaSyExampleKey1234")
+        '****1234'
+    """
+    if len(secret) < 12:
+        return REDACTED
+    return "****" + secret[-4:]
 
 
 @dataclass
@@ -16,6 +30,13 @@ class ClientConfig:
         retry_backoff_factor: Exponential backoff factor for retries
         max_backoff: Maximum backoff time between retries in seconds
         verify_ssl: Whether to verify SSL certificates
+        default_require_plan_approval: Require plan approval on created sessions
+            unless the caller sets it explicitly
+        default_page_size: Page size for list calls that do not specify one
+        extra_headers: Headers sent with every request, e.g. for API gateways
+
+    The repr never includes the API key or credential headers, so a config
+    can be logged as-is; see redacted().
     """
 
     api_key: str
@@ -25,6 +46,9 @@ class ClientConfig:
     retry_backoff_factor: float = 1.0
     max_backoff: float = 10.0
     verify_ssl: bool = True
+    default_require_plan_approval: bool = False
+    default_page_size: Optional[int] = None
+    extra_headers: Dict[str, str] = field(default_factory=dict)
 
     def __post_init__(self) -> None:
         """Validate configuration after initialization."""
@@ -40,6 +64,36 @@ class ClientConfig:
         if self.retry_backoff_factor <= 0:
             raise ValueError("Retry backoff factor must be positive")
 
+        if self.default_page_size is not None and self.default_page_size <= 0:
+            raise ValueError("Default page size must be positive")
+
+    def redacted(self) -> "ClientConfig":
+        """Return a copy that is safe to print, log or attach to bug reports.
+
+        The API key is masked down to its last 4 characters and credential
+        headers such as Authorization are replaced with REDACTED.
+
+        Example:
+            >>> logger.info(f"Starting with {config.redacted().to_dict()}")
+        """
+        headers = {
+            k: REDACTED if k.lower() in REDACTED_HEADERS else v
+            for k, v in self.extra_headers.items()
+        }
+        return replace(self, api_key=mask_secret(self.api_key), extra_headers=headers)
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Convert to a dictionary, e.g. for JSON diagnostics.
+
+        The result includes the API key; call redacted().to_dict() for output.
+        """
+        return asdict(self)
+
+    def __repr__(self) -> str:
+        """Show the effective configuration with secrets redacted."""
+        fields = ", ".join(f"{k}={v!r}" for k, v in self.redacted().to_dict().items())
+        return f"ClientConfig({fields})"
+
 
 # Default constants
 DEFAULT_TIMEOUT = 30
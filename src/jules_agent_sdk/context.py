@@ -0,0 +1,137 @@
+"""Per-call request context for multi-tenant use of a shared client."""
+
+from contextlib import contextmanager
+from contextvars import ContextVar
+from typing import Any, Dict, Iterator, Mapping, Optional, Tuple
+
+DEFAULT_CORRELATION_ID_HEADER = "X-Correlation-ID"
+
+_api_key_override: ContextVar[Optional[str]] = ContextVar(
+    "jules_api_key_override", default=None
+)
+_extra_headers: ContextVar[Dict[str, str]] = ContextVar("jules_extra_headers", default={})
+_correlation_id: ContextVar[Optional[str]] = ContextVar("jules_correlation_id", default=None)
+_attempt: ContextVar[Optional[int]] = ContextVar("jules_attempt", default=None)
+_request_counters: ContextVar[Tuple[Any, ...]] = ContextVar("jules_request_counters", default=())
+
+
+@contextmanager
+def use_api_key(api_key: str) -> Iterator[None]:
+    """Override the client's API key for calls made inside the block.
+
+    The override is stored in a context variable, so it applies to the current
+    thread or asyncio task only and one client can serve many tenants.
+
+    Args:
+        api_key: API key to send instead of the client's own
+
+    Raises:
+        ValueError: If api_key is empty
+
+    Example:
+        >>> with use_api_key(tenant.jules_api_key):
+        ...     session = client.sessions.create(prompt="Fix bug", source="sources/repo")
+    """
+    if not api_key:
+        raise ValueError("API key is required")
+
+    token = _api_key_override.set(api_key)
+    try:
+        yield
+    finally:
+        _api_key_override.reset(token)
+
+
+def current_api_key() -> Optional[str]:
+    """Get the API key override active in the current context, if any."""
+    return _api_key_override.get()
+
+
+@contextmanager
+def use_headers(headers: Mapping[str, str]) -> Iterator[None]:
+    """Add headers to calls made inside the block.
+
+    Blocks nest: inner headers are merged over outer ones. Per-call headers
+    override the client's extra_headers, but not an API key set with
+    use_api_key().
+
+    Args:
+        headers: Header names and values to send
+
+    Example:
+        >>> with use_headers({"X-Cost-Center": "build-infra"}):
+        ...     client.sessions.list()
+    """
+    token = _extra_headers.set({**_extra_headers.get(), **headers})
+    try:
+        yield
+    finally:
+        _extra_headers.reset(token)
+
+
+def current_headers() -> Dict[str, str]:
+    """Get the per-call headers active in the current context."""
+    return dict(_extra_headers.get())
+
+
+@contextmanager
+def use_correlation_id(correlation_id: str) -> Iterator[None]:
+    """Tag calls made inside the block with a correlation ID.
+
+    With the default correlation_id_extractor, the ID is sent in the client's
+    correlation header and attached to log records and raised errors.
+
+    Args:
+        correlation_id: Trace or request ID from the calling system
+
+    Example:
+        >>> with use_correlation_id(request.headers["X-Request-ID"]):
+        ...     client.sessions.create(prompt="Fix bug", source="sources/repo")
+    """
+    token = _correlation_id.set(correlation_id)
+    try:
+        yield
+    finally:
+        _correlation_id.reset(token)
+
+
+def current_correlation_id() -> Optional[str]:
+    """Get the correlation ID active in the current context, if any."""
+    return _correlation_id.get()
+
+
+@contextmanager
+def attempt_scope(attempt: int) -> Iterator[None]:
+    """Mark the HTTP attempt being made inside the block, for middlewares."""
+    token = _attempt.set(attempt)
+    try:
+        yield
+    finally:
+        _attempt.reset(token)
+
+
+def current_attempt() -> Optional[int]:
+    """Get the 1-based number of the HTTP attempt in progress, if any."""
+    return _attempt.get()
+
+
+@contextmanager
+def track_requests(counter: Any) -> Iterator[None]:
+    """Count the API requests made inside the block on counter.requests.
+
+    Blocks nest: a request is counted on every active counter.
+
+    Args:
+        counter: Object with an integer requests attribute, e.g. WaitStats
+    """
+    token = _request_counters.set(_request_counters.get() + (counter,))
+    try:
+        yield
+    finally:
+        _request_counters.reset(token)
+
+
+def note_request() -> None:
+    """Count one API request on the counters active in the current context."""
+    for counter in _request_counters.get():
+        counter.requests += 1
@@ -0,0 +1,237 @@
+"""Configurable strictness for decoding API responses into models."""
+
+import dataclasses
+import logging
+import re
+import threading
+from dataclasses import dataclass
+from decimal import Decimal
+from enum import Enum
+from typing import (
+    Any,
+    Dict,
+    Iterator,
+    Set,
+    Tuple,
+    Type,
+    TypeVar,
+    Union,
+    get_args,
+    get_origin,
+    get_type_hints,
+)
+
+from jules_agent_sdk.exceptions import JulesAPIError
+
+logger = logging.getLogger(__name__)
+
+T = TypeVar("T")
+
+# Kinds of schema problems, each enabled by one DecodeOptions flag
+UNKNOWN_FIELD = "unknown_field"
+BAD_TIMESTAMP = "bad_timestamp"
+TYPE_MISMATCH = "type_mismatch"
+
+_warned: Set[str] = set()
+_warned_lock = threading.Lock()
+
+# RFC 3339 timestamps as produced by the API, e.g. 2024-01-01T00:00:00.123456Z
+_RFC3339 = re.compile(r"^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$")
+
+
+@dataclass(frozen=True)
+class DecodeOptions:
+    """How strictly API responses are decoded.
+
+    The defaults match the SDK's historical behavior: unknown fields are
+    ignored, numbers become int/float and timestamps are kept as given.
+
+    Attributes:
+        use_decimal: Decode JSON numbers with a fraction as decimal.Decimal
+            instead of float, so no precision is lost
+        strict_timestamps: Require *Time fields to be RFC 3339 timestamps
+        reject_unknown_fields: Fail on response fields the models do not know,
+            surfacing API schema changes instead of silently dropping data
+        strict_types: Fail on values whose JSON type does not match the model
+            field, e.g. a number where a string is expected
+        log_mismatches: Log a warning, once per field path, for every problem
+            the strict checks would find but that is not enabled, so model drift
+            against the API shows up in lenient mode too
+    """
+
+    use_decimal: bool = False
+    strict_timestamps: bool = False
+    reject_unknown_fields: bool = False
+    strict_types: bool = False
+    log_mismatches: bool = False
+
+    @property
+    def checks_schema(self) -> bool:
+        """Whether decoded data has to be walked before building models."""
+        return (
+            self.strict_timestamps
+            or self.reject_unknown_fields
+            or self.strict_types
+            or self.log_mismatches
+        )
+
+    def is_fatal(self, kind: str) -> bool:
+        """Whether a problem of the given kind fails decoding."""
+        return {
+            UNKNOWN_FIELD: self.reject_unknown_fields,
+            BAD_TIMESTAMP: self.strict_timestamps,
+            TYPE_MISMATCH: self.strict_types,
+        }[kind]
+
+
+class DecodeError(JulesAPIError):
+    """Raised when a response does not match the models under strict decoding."""
+
+    def __init__(self, message: str, path: str) -> None:
+        """Initialize the exception.
+
+        Args:
+            message: Error message
+            path: Location of the offending value, e.g. "Session.sourceContext.foo"
+        """
+        super().__init__(f"{path}: {message}")
+        self.path = path
+
+
+def _camel(name: str) -> str:
+    """Convert a model field name to its API key."""
+    head, *rest = name.split("_")
+    return head + "".join(part.title() for part in rest)
+
+
+def _model_type(annotation: Any) -> Any:
+    """Unwrap Optional[X] and List[X] to the dataclass X, if any."""
+    origin = get_origin(annotation)
+    if origin in (Union, list):
+        for arg in get_args(annotation):
+            found = _model_type(arg)
+            if found is not None:
+                return found
+        return None
+    if dataclasses.is_dataclass(annotation):
+        return annotation
+    return None
+
+
+def _json_type_matches(annotation: Any, value: Any) -> bool:
+    """Whether a decoded JSON value fits a model field annotation."""
+    if value is None or annotation is Any:
+        return True
+    origin = get_origin(annotation)
+    if origin is Union:
+        return any(_json_type_matches(arg, value) for arg in get_args(annotation))
+    if origin is list:
+        return isinstance(value, list)
+    if origin is dict or dataclasses.is_dataclass(annotation):
+        return isinstance(value, dict)
+    if isinstance(annotation, type) and issubclass(annotation, Enum):
+        return isinstance(value, str)
+    if annotation is bool:
+        return isinstance(value, bool)
+    if annotation is int:
+        return isinstance(value, int) and not isinstance(value, bool)
+    if annotation is float:
+        return isinstance(value, (int, float, Decimal)) and not isinstance(value, bool)
+    if annotation is str:
+        return isinstance(value, str)
+    if annotation is type(None):
+        # The None arm of Optional[X] must not accept values of any other type
+        return value is None
+    return True
+
+
+def problems(model: Type[Any], data: Any, path: str = "") -> Iterator[Tuple[str, str, str]]:
+    """Find every way response data deviates from a model.
+
+    Args:
+        model: Model dataclass the data will be decoded into
+        data: Decoded JSON for one model instance
+        path: Location of data, used in messages
+
+    Yields:
+        (kind, path, message) tuples, where kind is UNKNOWN_FIELD, BAD_TIMESTAMP
+        or TYPE_MISMATCH
+    """
+    path = path or model.__name__
+    if not isinstance(data, dict):
+        return
+
+    hints = get_type_hints(model)
+    fields = {_camel(f.name): f for f in dataclasses.fields(model)}
+
+    for key, value in data.items():
+        field = fields.get(key)
+        if field is None:
+            is_extension = getattr(model, "is_extension_key", None)
+            if is_extension is None or not is_extension(key):
+                yield UNKNOWN_FIELD, f"{path}.{key}", "unknown field"
+            continue
+
+        if field.name.endswith("_time") and value:
+            if not isinstance(value, str) or not _RFC3339.match(value):
+                yield BAD_TIMESTAMP, f"{path}.{key}", f"not an RFC 3339 timestamp: {value!r}"
+                continue
+
+        if not _json_type_matches(hints[field.name], value):
+            yield TYPE_MISMATCH, f"{path}.{key}", f"unexpected {type(value).__name__}: {value!r}"
+            continue
+
+        nested = _model_type(hints[field.name])
+        if nested is None:
+            continue
+        items = value if isinstance(value, list) else [value]
+        for index, item in enumerate(items):
+            suffix = f"[{index}]" if isinstance(value, list) else ""
+            yield from problems(nested, item, f"{path}.{key}{suffix}")
+
+
+def _warn_once(path: str, message: str) -> None:
+    """Log a schema mismatch warning, once per process for each path."""
+    with _warned_lock:
+        if path in _warned:
+            return
+        _warned.add(path)
+    logger.warning(f"Response does not match the SDK models: {path}: {message}")
+
+
+def check(model: Type[Any], data: Any, options: DecodeOptions, path: str = "") -> None:
+    """Validate response data against a model under the given options.
+
+    Args:
+        model: Model dataclass the data will be decoded into
+        data: Decoded JSON for one model instance
+        options: Decoding options
+        path: Location of data, used in error messages
+
+    Raises:
+        DecodeError: If the data violates an enabled check
+    """
+    for kind, where, message in problems(model, data, path):
+        if options.is_fatal(kind):
+            raise DecodeError(message, where)
+        if options.log_mismatches:
+            _warn_once(where, message)
+
+
+def decode(model: Type[T], data: Dict[str, Any], options: DecodeOptions) -> T:
+    """Build a model from response data, applying the enabled checks first.
+
+    Args:
+        model: Model dataclass with a from_dict classmethod
+        data: Decoded JSON
+        options: Decoding options
+
+    Returns:
+        The model instance
+
+    Raises:
+        DecodeError: If the data violates an enabled check
+    """
+    if options.checks_schema:
+        check(model, data, options)
+    return model.from_dict(data)  # type: ignore[attr-defined,no-any-return]
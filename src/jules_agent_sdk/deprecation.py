@@ -0,0 +1,121 @@
+"""Deprecation of the API endpoints behind the SDK.
+
+Endpoints the server marks with Deprecation or Sunset response headers are
+recorded as DeprecationNotice objects and logged once per endpoint.
+"""
+
+import logging
+import re
+import threading
+from dataclasses import dataclass
+from datetime import datetime, timezone
+from email.utils import parsedate_to_datetime
+from typing import Dict, List, Mapping, Optional
+
+logger = logging.getLogger(__name__)
+
+# Link header entries pointing at deprecation or sunset documentation
+_LINK = re.compile(r'<([^>]*)>[^,]*?rel="?(?:deprecation|sunset)"?', re.IGNORECASE)
+
+
+def _parse_date(value: str) -> Optional[datetime]:
+    """Parse an HTTP date or an RFC 9745 "@<unix seconds>" date."""
+    value = value.strip()
+    if value.startswith("@"):
+        try:
+            return datetime.fromtimestamp(int(value[1:]), timezone.utc)
+        except (ValueError, OverflowError):
+            return None
+    try:
+        when = parsedate_to_datetime(value)
+    except (TypeError, ValueError):
+        return None
+    return when if when.tzinfo else when.replace(tzinfo=timezone.utc)
+
+
+@dataclass(frozen=True)
+class DeprecationNotice:
+    """An endpoint the server announced as deprecated or scheduled for removal.
+
+    Attributes:
+        endpoint: Method and templated path, e.g. "GET /v1alpha/sessions/{id}"
+        deprecated_at: When the endpoint was or will be deprecated, if dated
+        sunset_at: When the endpoint will stop working, if announced
+        link: URL with migration details, if the server sent one
+    """
+
+    endpoint: str
+    deprecated_at: Optional[datetime] = None
+    sunset_at: Optional[datetime] = None
+    link: Optional[str] = None
+
+    def __str__(self) -> str:
+        """Describe the notice for logs."""
+        message = f"{self.endpoint} is deprecated"
+        if self.sunset_at:
+            message += f" and will be removed on {self.sunset_at.date().isoformat()}"
+        if self.link:
+            message += f"; see {self.link}"
+        return message
+
+
+def parse_deprecation_headers(
+    endpoint: str, headers: Mapping[str, str]
+) -> Optional[DeprecationNotice]:
+    """Build a notice from Deprecation, Sunset and Link response headers.
+
+    Args:
+        endpoint: Method and templated path the response belongs to
+        headers: Response headers (case-insensitive mapping)
+
+    Returns:
+        The notice, or None if the response carries neither header
+    """
+    deprecation = headers.get("Deprecation")
+    sunset = headers.get("Sunset")
+    if not deprecation and not sunset:
+        return None
+    if deprecation and deprecation.strip().lower() == "false" and not sunset:
+        return None
+
+    link = _LINK.search(headers.get("Link") or "")
+    return DeprecationNotice(
+        endpoint=endpoint,
+        deprecated_at=_parse_date(deprecation) if deprecation else None,
+        sunset_at=_parse_date(sunset) if sunset else None,
+        link=link.group(1) if link else None,
+    )
+
+
+class DeprecationTracker:
+    """Collects deprecation notices seen by a client, logging each endpoint once."""
+
+    def __init__(self) -> None:
+        """Initialize an empty tracker."""
+        self._notices: Dict[str, DeprecationNotice] = {}
+        self._lock = threading.Lock()
+
+    def observe(self, endpoint: str, headers: Mapping[str, str]) -> None:
+        """Record the notice carried by a response's headers, if any.
+
+        Args:
+            endpoint: Method and templated path the response belongs to
+            headers: Response headers
+        """
+        if not isinstance(headers, Mapping):
+            return
+        if "Deprecation" not in headers and "Sunset" not in headers:
+            return
+        notice = parse_deprecation_headers(endpoint, headers)
+        if notice is None:
+            return
+        with self._lock:
+            if endpoint in self._notices:
+                return
+            self._notices[endpoint] = notice
+        logger.warning(str(notice))
+
+    def notices(self) -> List[DeprecationNotice]:
+        """Return the notices seen so far, in the order they were first seen."""
+        with self._lock:
+            return list(self._notices.values())
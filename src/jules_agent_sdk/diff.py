@@ -0,0 +1,104 @@
+"""Streaming parser for unified diff patches."""
+
+import io
+import re
+from dataclasses import dataclass, field
+from typing import Iterable, Iterator, List, Optional, Union
+
+_HUNK_HEADER = re.compile(r"^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$")
+
+
+@dataclass
+class Hunk:
+    """A single hunk of a unified diff."""
+
+    old_path: str
+    new_path: str
+    old_start: int
+    old_count: int
+    new_start: int
+    new_count: int
+    section: str = ""
+    lines: List[str] = field(default_factory=list)
+
+    @property
+    def added(self) -> int:
+        """Number of added lines."""
+        return sum(1 for line in self.lines if line.startswith("+"))
+
+    @property
+    def removed(self) -> int:
+        """Number of removed lines."""
+        return sum(1 for line in self.lines if line.startswith("-"))
+
+
+def _strip_prefix(path: str) -> str:
+    """Drop the a/ or b/ prefix git adds to diff paths."""
+    path = path.split("\t", 1)[0]
+    if path.startswith(("a/", "b/")):
+        return path[2:]
+    return path
+
+
+def iter_hunks(patch: Union[str, Iterable[str]]) -> Iterator[Hunk]:
+    """Yield the hunks of a unified diff one at a time.
+
+    Only the hunk being parsed is held in memory, so passing a file object or
+    another line iterator keeps memory use flat regardless of patch size.
+
+    Args:
+        patch: Unified diff text, or an iterable of its lines
+
+    Yields:
+        Hunk objects in patch order
+
+    Example:
+        >>> for hunk in iter_hunks(git_patch.unidiff_patch):
+        ...     print(hunk.new_path, f"+{hunk.added} -{hunk.removed}")
+    """
+    lines: Iterable[str] = io.StringIO(patch) if isinstance(patch, str) else patch
+
+    old_path = new_path = ""
+    hunk: Optional[Hunk] = None
+    old_left = new_left = 0
+
+    for raw in lines:
+        line = raw.rstrip("\r\n")
+
+        if hunk is not None:
+            if line.startswith("\\"):
+                # "\ No newline at end of file" applies to the previous line
+                hunk.lines.append(line)
+                continue
+            if old_left > 0 or new_left > 0:
+                hunk.lines.append(line)
+                if not line.startswith("+"):
+                    old_left -= 1
+                if not line.startswith("-"):
+                    new_left -= 1
+                continue
+            yield hunk
+            hunk = None
+
+        if line.startswith("--- "):
+            old_path = _strip_prefix(line[4:])
+        elif line.startswith("+++ "):
+            new_path = _strip_prefix(line[4:])
+        else:
+            match = _HUNK_HEADER.match(line)
+            if match:
+                old_start, old_count, new_start, new_count, section = match.groups()
+                old_left = int(old_count) if old_count is not None else 1
+                new_left = int(new_count) if new_count is not None else 1
+                hunk = Hunk(
+                    old_path=old_path,
+                    new_path=new_path,
+                    old_start=int(old_start),
+                    old_count=old_left,
+                    new_start=int(new_start),
+                    new_count=new_left,
+                    section=section,
+                )
+
+    if hunk is not None:
+        yield hunk
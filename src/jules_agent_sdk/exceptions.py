@@ -1,6 +1,14 @@
 """Custom exceptions for the Jules Agent SDK."""
 
-from typing import Optional, Dict, Any
+import asyncio
+from functools import lru_cache
+from typing import TYPE_CHECKING, Optional, Dict, Any, List, Type
+
+import aiohttp
+import requests
+
+if TYPE_CHECKING:
+    from jules_agent_sdk.models import Plan
 
 
 class JulesAPIError(Exception):
@@ -24,6 +32,31 @@ class JulesAPIError(Exception):
         self.status_code = status_code
         self.response = response
 
+        # Request context, filled in by the client when the error is raised
+        self.operation: Optional[str] = None
+        self.resource: Optional[str] = None
+        self.attempts: Optional[int] = None
+        self.correlation_id: Optional[str] = None
+        # Server-assigned ID of the failed request, to quote to Google support
+        self.request_id: Optional[str] = None
+
+    def __str__(self) -> str:
+        """Format the message followed by any request context."""
+        context = [
+            f"{key}={value}"
+            for key, value in (
+                ("operation", self.operation),
+                ("resource", self.resource),
+                ("attempts", self.attempts),
+                ("correlation_id", self.correlation_id),
+                ("request_id", self.request_id),
+            )
+            if value is not None
+        ]
+        if not context:
+            return self.message
+        return f"{self.message} ({', '.join(context)})"
+
 
 class JulesAuthenticationError(JulesAPIError):
     """Raised when authentication fails (401)."""
@@ -43,6 +76,64 @@ class JulesValidationError(JulesAPIError):
     pass
 
 
+class InvalidResourceNameError(JulesValidationError):
+    """Raised locally when an ID, resource name or URL cannot be turned into a path."""
+
+    def __init__(self, message: str, value: str) -> None:
+        """Initialize the exception.
+
+        Args:
+            message: Error message
+            value: The rejected input
+        """
+        super().__init__(message)
+        self.value = value
+
+
+class SourceNotFoundError(JulesNotFoundError):
+    """Raised when a repository cannot be resolved to an accessible source."""
+
+    def __init__(self, query: str, suggestions: List[str]) -> None:
+        """Initialize the exception.
+
+        Args:
+            query: The "owner/repo" that was looked up
+            suggestions: Accessible repositories with similar names, best match first
+        """
+        message = f"No source found for repository {query!r}"
+        if suggestions:
+            message += f"; did you mean {', '.join(repr(s) for s in suggestions)}?"
+        super().__init__(message, 404)
+        self.query = query
+        self.suggestions = suggestions
+
+
+class BranchNotFoundError(JulesValidationError):
+    """Raised locally when a session's starting branch does not exist in its source."""
+
+    def __init__(self, branch: str, source: str, suggestions: List[str]) -> None:
+        """Initialize the exception.
+
+        Args:
+            branch: The requested starting branch
+            source: The source that was checked
+            suggestions: Existing branches with similar names, best match first
+        """
+        message = f"Branch {branch!r} not found in {source}"
+        if suggestions:
+            message += f"; did you mean {', '.join(repr(s) for s in suggestions)}?"
+        super().__init__(message)
+        self.branch = branch
+        self.source = source
+        self.suggestions = suggestions
+
+
+class ReadOnlyModeError(JulesAPIError):
+    """Raised locally when a read-only client is asked to make a mutating call."""
+
+    pass
+
+
 class JulesRateLimitError(JulesAPIError):
     """Raised when rate limit is exceeded (429)."""
 
@@ -53,3 +144,307 @@ class JulesServerError(JulesAPIError):
     """Raised when server returns 5xx error."""
 
     pass
+
+
+class CircuitOpenError(JulesAPIError):
+    """Raised locally while a circuit breaker fails fast for a failing endpoint."""
+
+    def __init__(self, endpoint: str, retry_after: float) -> None:
+        """Initialize the exception.
+
+        Args:
+            endpoint: Base URL whose circuit is open
+            retry_after: Seconds until the circuit lets a trial request through
+        """
+        super().__init__(
+            f"Circuit open for {endpoint} after repeated failures; "
+            f"failing fast for another {retry_after:.1f}s",
+            response={"retry_after_seconds": retry_after},
+        )
+        self.endpoint = endpoint
+        self.retry_after = retry_after
+
+
+class JulesTimeoutError(JulesAPIError, TimeoutError):
+    """Raised when waiting on a session exceeds its timeout.
+
+    Subclasses the built-in TimeoutError, so existing ``except TimeoutError``
+    handlers keep working.
+    """
+
+    def __init__(self, session_id: str, timeout: float, elapsed: float) -> None:
+        """Initialize the exception.
+
+        Args:
+            session_id: Full resource name of the session being waited on
+            timeout: Configured timeout in seconds
+            elapsed: Seconds spent waiting before giving up
+        """
+        super().__init__(
+            f"Session polling timed out after {timeout} seconds "
+            f"({session_id}, elapsed {elapsed:.1f}s)"
+        )
+        self.session_id = session_id
+        self.timeout = timeout
+        self.elapsed = elapsed
+
+
+class PollLimitExceededError(JulesAPIError):
+    """Raised when waiting on a session uses up its max_polls budget."""
+
+    def __init__(self, session_id: str, max_polls: int) -> None:
+        """Initialize the exception.
+
+        Args:
+            session_id: Full resource name of the session being waited on
+            max_polls: Configured maximum number of polls
+        """
+        super().__init__(f"Session {session_id} did not finish within {max_polls} polls")
+        self.session_id = session_id
+        self.max_polls = max_polls
+
+
+class SessionStalledError(JulesAPIError):
+    """Raised when a waited-on session stops making progress."""
+
+    def __init__(self, session_id: str, state: str, stalled_for: float) -> None:
+        """Initialize the exception.
+
+        Args:
+            session_id: Full resource name of the session being waited on
+            state: State the session is stuck in
+            stalled_for: Seconds without progress before giving up
+        """
+        super().__init__(f"Session {session_id} made no progress in {state} for {stalled_for:.1f}s")
+        self.session_id = session_id
+        self.state = state
+        self.stalled_for = stalled_for
+
+
+class PlanSupersededError(JulesAPIError):
+    """Raised when a plan could not be approved because the agent replaced it."""
+
+    def __init__(self, session_id: str, plan_id: str, plan: Optional["Plan"]) -> None:
+        """Initialize the exception.
+
+        Args:
+            session_id: Full resource name of the session
+            plan_id: The plan the caller tried to approve
+            plan: The plan now awaiting approval, if any
+        """
+        current = plan.id if plan else "none"
+        super().__init__(
+            f"Plan {plan_id} of {session_id} was superseded (current plan: {current})", 409
+        )
+        self.session_id = session_id
+        self.plan_id = plan_id
+        self.plan = plan
+
+
+class WaitCancelledError(JulesAPIError):
+    """Raised when a wait on a session is cancelled by the caller.
+
+    Only the wait stops; the session keeps running on the server.
+    """
+
+    def __init__(self, session_id: str, reason: Optional[str] = None) -> None:
+        """Initialize the exception.
+
+        Args:
+            session_id: Full resource name of the session being waited on
+            reason: Why the wait was cancelled, if recorded, e.g. "POLICY"
+        """
+        message = f"Wait for {session_id} was cancelled"
+        if reason:
+            message += f" ({reason})"
+        super().__init__(message)
+        self.session_id = session_id
+        self.reason = reason
+
+
+class UnexpectedContentTypeError(JulesAPIError):
+    """Raised when a response body is not JSON, e.g. an HTML page from a proxy.
+
+    Gateways, load balancers and captive portals answer with HTML. The original
+    status code is preserved, so 5xx responses are still retried. Errors built
+    with for_status() are also instances of the status code's exception class,
+    e.g. JulesNotFoundError for an HTML 404 page.
+    """
+
+    SNIPPET_LENGTH = 200
+
+    def __init__(self, status_code: Optional[int], content_type: str, body: str) -> None:
+        """Initialize the exception.
+
+        Args:
+            status_code: HTTP status code
+            content_type: Value of the Content-Type response header
+            body: Raw response body
+        """
+        snippet = " ".join(body.split())[: self.SNIPPET_LENGTH]
+        super().__init__(
+            f"Expected a JSON response but got {content_type or 'no content type'} "
+            f"(status {status_code}): {snippet}",
+            status_code,
+        )
+        self.content_type = content_type
+        self.body_snippet = snippet
+
+    @classmethod
+    def for_status(
+        cls, status_code: Optional[int], content_type: str, body: str
+    ) -> "UnexpectedContentTypeError":
+        """Build the error for a non-JSON body, typed by its status code.
+
+        Args:
+            status_code: HTTP status code
+            content_type: Value of the Content-Type response header
+            body: Raw response body
+
+        Returns:
+            An UnexpectedContentTypeError that callers catching the status code's
+            exception class, e.g. JulesNotFoundError, still catch
+        """
+        return _typed_content_type_error(status_error_class(status_code))(
+            status_code, content_type, body
+        )
+
+
+def status_error_class(status_code: Optional[int]) -> Type[JulesAPIError]:
+    """Get the exception class the clients raise for an HTTP error status.
+
+    Args:
+        status_code: HTTP status code
+
+    Returns:
+        The JulesAPIError subclass for the status, or JulesAPIError itself
+        for statuses without one (including 2xx)
+    """
+    classes: Dict[int, Type[JulesAPIError]] = {
+        400: JulesValidationError,
+        401: JulesAuthenticationError,
+        404: JulesNotFoundError,
+        429: JulesRateLimitError,
+    }
+    if status_code in classes:
+        return classes[status_code]
+    if (status_code or 0) >= 500:
+        return JulesServerError
+    return JulesAPIError
+
+
+@lru_cache(maxsize=None)
+def _typed_content_type_error(base: Type[JulesAPIError]) -> Type[UnexpectedContentTypeError]:
+    """Get the UnexpectedContentTypeError subclass that is also a base."""
+    if base is JulesAPIError:
+        return UnexpectedContentTypeError
+    name = "UnexpectedContentType" + base.__name__.replace("Jules", "", 1)
+    return type(name, (UnexpectedContentTypeError, base), {"__module__": __name__})
+
+
+class PaginationLoopError(JulesAPIError):
+    """Raised when a list_all loop stops making progress or exceeds its page limit."""
+
+    def __init__(self, message: str, pages: int, page_token: Optional[str]) -> None:
+        """Initialize the exception.
+
+        Args:
+            message: Error message
+            pages: Number of pages fetched before stopping
+            page_token: The next page token that was not followed
+        """
+        super().__init__(message)
+        self.pages = pages
+        self.page_token = page_token
+
+
+class PromptTooLargeError(JulesValidationError):
+    """Raised locally when a prompt is estimated to exceed the size limit."""
+
+    def __init__(self, estimated_tokens: int, max_tokens: int) -> None:
+        """Initialize the exception.
+
+        Args:
+            estimated_tokens: Estimated size of the prompt in tokens
+            max_tokens: Configured maximum prompt size in tokens
+        """
+        super().__init__(
+            f"Prompt is about {estimated_tokens} tokens, over the limit of {max_tokens}. "
+            "Shorten it, trim logs with prompt.truncate_prompt(), or split it with "
+            "prompt.chunk_prompt() and send the remainder via send_message()"
+        )
+        self.estimated_tokens = estimated_tokens
+        self.max_tokens = max_tokens
+
+
+# Transport failures worth retrying, from either HTTP stack
+_NETWORK_ERRORS = (
+    requests.exceptions.ConnectionError,
+    requests.exceptions.Timeout,
+    aiohttp.ClientConnectionError,
+    asyncio.TimeoutError,
+    ConnectionError,
+)
+
+
+def is_server_error(err: BaseException) -> bool:
+    """Check whether an error represents a 5xx response.
+
+    Args:
+        err: Error raised by the SDK
+
+    Returns:
+        True for JulesServerError and other API errors carrying a 5xx status
+    """
+    if isinstance(err, JulesServerError):
+        return True
+    return isinstance(err, JulesAPIError) and (err.status_code or 0) >= 500
+
+
+def is_retryable(err: BaseException) -> bool:
+    """Check whether an operation that failed with err is worth retrying.
+
+    Uses the same classification as the client's internal retries: server
+    errors, rate limiting and network failures are transient, everything else
+    (authentication, validation, not found) is not.
+
+    Args:
+        err: Error raised by the SDK
+
+    Returns:
+        True if retrying the operation may succeed
+
+    Example:
+        >>> try:
+        ...     session = client.sessions.create(prompt="Fix bug", source="sources/repo")
+        ... except JulesAPIError as e:
+        ...     if not is_retryable(e):
+        ...         raise
+    """
+    if isinstance(err, JulesTimeoutError):
+        return False
+    if isinstance(err, (JulesRateLimitError, CircuitOpenError)) or is_server_error(err):
+        return True
+
+    cause: Optional[BaseException] = err
+    while cause is not None:
+        if isinstance(cause, _NETWORK_ERRORS):
+            return True
+        cause = cause.__cause__
+    return False
+
+
+def retry_delay_hint(err: BaseException) -> Optional[float]:
+    """Get the delay the server asked for before retrying, if any.
+
+    Args:
+        err: Error raised by the SDK
+
+    Returns:
+        Seconds to wait, or None if the error carries no hint
+    """
+    if isinstance(err, (JulesRateLimitError, CircuitOpenError)) and err.response:
+        retry_after = err.response.get("retry_after_seconds")
+        if retry_after is not None:
+            return float(retry_after)
+    return None
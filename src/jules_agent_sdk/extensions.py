@@ -0,0 +1,65 @@
+"""Registry of decoders for activity event kinds the SDK does not model yet."""
+
+import threading
+from typing import Any, Callable, Dict, FrozenSet
+
+# Turns the raw JSON payload of an event into whatever object the caller wants
+ActivityDecoder = Callable[[Dict[str, Any]], Any]
+
+_decoders: Dict[str, ActivityDecoder] = {}
+_lock = threading.Lock()
+
+
+def register_activity_kind(key: str, decoder: ActivityDecoder, replace: bool = False) -> None:
+    """Register a decoder for an activity event key, e.g. "toolInvoked".
+
+    Activities keep the payloads of event keys the SDK does not know, so new
+    server features can be consumed before an SDK release: register a decoder
+    and read the event with Activity.event(key). Registered keys also count
+    as known fields under DecodeOptions(reject_unknown_fields=True) and are
+    reported by Activity.kind.
+
+    Args:
+        key: The API key of the event, as it appears in the activity JSON
+        decoder: Called with the event payload; its result is returned by
+            Activity.event()
+        replace: Allow replacing an existing decoder for key
+
+    Raises:
+        ValueError: If key is built in or already registered and replace is False
+
+    Example:
+        >>> register_activity_kind("toolInvoked", lambda data: data["toolName"])
+        >>> for activity in client.activities.list_all(session_id):
+        ...     if activity.kind == "toolInvoked":
+        ...         print(activity.event("toolInvoked"))
+    """
+    from jules_agent_sdk.models import ACTIVITY_EVENT_KEYS
+
+    if key in ACTIVITY_EVENT_KEYS:
+        raise ValueError(f"{key} is a built-in activity kind")
+    with _lock:
+        if key in _decoders and not replace:
+            raise ValueError(f"Activity kind {key} is already registered")
+        _decoders[key] = decoder
+
+
+def unregister_activity_kind(key: str) -> None:
+    """Remove the decoder for an activity event key, if any."""
+    with _lock:
+        _decoders.pop(key, None)
+
+
+def registered_activity_kinds() -> FrozenSet[str]:
+    """Return the event keys that have a registered decoder."""
+    with _lock:
+        return frozenset(_decoders)
+
+
+def decode_activity_event(key: str, payload: Any) -> Any:
+    """Decode an event payload with the registered decoder, or return it as is."""
+    with _lock:
+        decoder = _decoders.get(key)
+    if decoder is None:
+        return payload
+    return decoder(payload)
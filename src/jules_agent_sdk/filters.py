@@ -0,0 +1,130 @@
+"""Typed filters for listing sources.
+
+The sources endpoint accepts an AIP-160 filter expression, but only on the
+source name: one or more name=... comparisons joined by OR. SourceFilter
+builds that expression with proper quoting, and applies conditions the server
+cannot evaluate, such as the repository owner, on the client.
+"""
+
+import re
+from dataclasses import dataclass, replace
+from typing import Optional, Tuple, Union
+
+from jules_agent_sdk.models import Source
+from jules_agent_sdk.resources import source_path
+from jules_agent_sdk.sourcemap import is_repo_shorthand
+
+# Values made only of these characters are sent unquoted, as in the API docs
+_BARE_VALUE = re.compile(r"^[A-Za-z0-9_./-]+$")
+
+
+def quote_filter_value(value: str) -> str:
+    """Quote a value for an AIP-160 filter expression, if it needs it.
+
+    Example:
+        >>> quote_filter_value("sources/github/octo/app")
+        'sources/github/octo/app'
+        >>> quote_filter_value("my repo")
+        '"my repo"'
+    """
+    if _BARE_VALUE.match(value):
+        return value
+    escaped = value.replace("\\", "\\\\").replace('"', '\\"')
+    return f'"{escaped}"'
+
+
+@dataclass(frozen=True)
+class SourceFilter:
+    """Filter for SourcesAPI.list and friends, in place of a raw filter string.
+
+    Build one with the class methods and combine them with &. Name conditions
+    are sent to the server; owner and visibility are checked on the client,
+    so a page may come back with fewer sources than page_size.
+
+    Example:
+        >>> f = SourceFilter.named("octo/app", "octo/api") & SourceFilter.private()
+        >>> f.expression()
+        'name=sources/github/octo/app OR name=sources/github/octo/api'
+        >>> client.sources.list_all(filter_str=SourceFilter.owned_by("octo"))
+    """
+
+    names: Tuple[str, ...] = ()
+    owner: Optional[str] = None
+    is_private: Optional[bool] = None
+
+    @classmethod
+    def named(cls, *sources: str) -> "SourceFilter":
+        """Match any of the given sources.
+
+        Args:
+            *sources: Resource names, source IDs or GitHub "owner/repo" shorthands
+        """
+        if not sources:
+            raise ValueError("SourceFilter.named needs at least one source")
+        names = tuple(
+            f"sources/github/{s.strip()}" if is_repo_shorthand(s) else source_path(s)
+            for s in sources
+        )
+        return cls(names=names)
+
+    @classmethod
+    def owned_by(cls, owner: str) -> "SourceFilter":
+        """Match GitHub repositories of an owner (user or organization)."""
+        return cls(owner=owner)
+
+    @classmethod
+    def private(cls, is_private: bool = True) -> "SourceFilter":
+        """Match private repositories, or public ones with is_private=False."""
+        return cls(is_private=is_private)
+
+    def __and__(self, other: "SourceFilter") -> "SourceFilter":
+        """Match sources matching both filters."""
+        names = self.names or other.names
+        if self.names and other.names:
+            names = tuple(n for n in self.names if n in other.names)
+            if not names:
+                raise ValueError("SourceFilter names do not overlap; nothing can match")
+        for attr in ("owner", "is_private"):
+            mine, theirs = getattr(self, attr), getattr(other, attr)
+            if mine is not None and theirs is not None and mine != theirs:
+                raise ValueError(f"Conflicting SourceFilter {attr}: {mine!r} and {theirs!r}")
+        return replace(
+            self,
+            names=names,
+            owner=self.owner if self.owner is not None else other.owner,
+            is_private=self.is_private if self.is_private is not None else other.is_private,
+        )
+
+    def expression(self) -> Optional[str]:
+        """Build the server-side filter expression, or None if there is none."""
+        if not self.names:
+            return None
+        return " OR ".join(f"name={quote_filter_value(n)}" for n in self.names)
+
+    def matches(self, source: Source) -> bool:
+        """Check a source against every condition, including client-side ones."""
+        if self.names and source.name not in self.names:
+            return False
+        repo = source.github_repo
+        if self.owner is not None and (repo is None or repo.owner.lower() != self.owner.lower()):
+            return False
+        if self.is_private is not None and (repo is None or repo.is_private != self.is_private):
+            return False
+        return True
+
+    def __str__(self) -> str:
+        """Show the server-side expression."""
+        return self.expression() or ""
+
+
+# A raw AIP-160 filter string or a SourceFilter
+SourceFilterLike = Union[str, SourceFilter]
+
+
+def split_filter(
+    filter_str: Optional[SourceFilterLike],
+) -> Tuple[Optional[str], Optional[SourceFilter]]:
+    """Split a filter into the server expression and a client-side filter, if any."""
+    if isinstance(filter_str, SourceFilter):
+        return filter_str.expression(), filter_str
+    return filter_str, None
@@ -0,0 +1,234 @@
+"""Sub-clients bound to a single resource."""
+
+import logging
+from datetime import datetime
+from typing import Any, Dict, List, Optional, Tuple
+
+from jules_agent_sdk.activities import ActivitiesAPI
+from jules_agent_sdk.artifacts import ArtifactIndex
+from jules_agent_sdk.models import Activity, CompletionDetails, PlanProgress, Session, Source
+from jules_agent_sdk.pagination import DEFAULT_MAX_PAGES, PageTracker
+from jules_agent_sdk.sessions import SessionsAPI
+from jules_agent_sdk.sources import SourcesAPI
+
+logger = logging.getLogger(__name__)
+
+
+class SessionHandle:
+    """A session-scoped view of the client.
+
+    Every method forwards to the client's APIs with the bound session ID, so
+    code that works with one session does not repeat it on every call.
+
+    Example:
+        >>> handle = client.session("abc123")
+        >>> handle.send_message("Please also add unit tests")
+        >>> final = handle.wait(timeout=1800)
+
+    Attributes:
+        name: Full resource name, e.g. "sessions/abc123"
+        id: Bare session ID
+        logger: Logger adapter that tags records with the session and log_fields
+    """
+
+    def __init__(
+        self,
+        sessions: SessionsAPI,
+        activities: ActivitiesAPI,
+        name: str,
+        log_fields: Optional[Dict[str, Any]] = None,
+    ) -> None:
+        """Initialize the handle.
+
+        Args:
+            sessions: Sessions API to forward to
+            activities: Activities API to forward to
+            name: Full session resource name
+            log_fields: Extra fields attached to this handle's log records
+        """
+        self.sessions = sessions
+        self.activities_api = activities
+        self.name = name
+        self.id = name.split("/", 1)[1]
+        self.logger = logging.LoggerAdapter(
+            logger, {"session_id": self.id, **(log_fields or {})}
+        )
+
+    def __repr__(self) -> str:
+        """Show the bound session."""
+        return f"SessionHandle({self.name})"
+
+    def get(self) -> Session:
+        """Get the session.
+
+        Returns:
+            Session object
+        """
+        return self.sessions.get(self.name)
+
+    def approve_plan(self, plan_id: Optional[str] = None) -> None:
+        """Approve the session's pending plan.
+
+        Args:
+            plan_id: ID of the plan being approved, to detect a superseded plan
+        """
+        self.logger.info(f"Approving plan for {self.name}")
+        self.sessions.approve_plan(self.name, plan_id=plan_id)
+
+    def approve_plan_and_wait(self, plan_id: Optional[str] = None, **kwargs: Any) -> Session:
+        """Approve the session's pending plan and wait for the session to complete.
+
+        Args:
+            plan_id: ID of the plan being approved, to detect a superseded plan
+            **kwargs: Options accepted by SessionsAPI.approve_plan_and_wait
+
+        Returns:
+            Final Session object
+        """
+        self.logger.info(f"Approving plan for {self.name}")
+        return self.sessions.approve_plan_and_wait(self.name, plan_id=plan_id, **kwargs)
+
+    def send_message(self, prompt: str) -> None:
+        """Send a message from the user to the session.
+
+        Args:
+            prompt: The message to send
+        """
+        self.logger.info(f"Sending message to {self.name}")
+        self.sessions.send_message(self.name, prompt)
+
+    def activities(self, page_size: Optional[int] = None) -> List[Activity]:
+        """List all activities of the session.
+
+        Args:
+            page_size: Activities fetched per request
+
+        Returns:
+            List of Activity objects
+        """
+        return self.activities_api.list_all(self.name, page_size=page_size)
+
+    def last_agent_message(self) -> Tuple[str, Optional[datetime]]:
+        """Get the most recent agent message and its create time.
+
+        Returns:
+            Tuple of (message, create time as a datetime)
+        """
+        return self.sessions.last_agent_message(self.name)
+
+    def completion_details(self) -> CompletionDetails:
+        """Get the outputs and summary of the completed session.
+
+        Returns:
+            CompletionDetails object
+        """
+        return self.sessions.get_completion_details(self.name)
+
+    def progress(self) -> Optional[PlanProgress]:
+        """Get how far the session has got through its plan.
+
+        Returns:
+            PlanProgress, or None if the session has no plan yet
+        """
+        return self.sessions.get_progress(self.name)
+
+    def artifacts(self) -> ArtifactIndex:
+        """Get an index of all artifacts produced by the session.
+
+        Returns:
+            ArtifactIndex, loaded on first access
+        """
+        return self.sessions.artifacts(self.name)
+
+    def wait(self, **kwargs: Any) -> Session:
+        """Poll the session until it completes or fails.
+
+        Args:
+            **kwargs: Options accepted by SessionsAPI.wait_for_completion
+
+        Returns:
+            Final Session object
+        """
+        self.logger.debug(f"Waiting for {self.name}")
+        return self.sessions.wait_for_completion(self.name, **kwargs)
+
+
+class SourceHandle:
+    """A source-scoped view of the client, for repo-centric tools.
+
+    Example:
+        >>> repo = client.source("octo/app")
+        >>> session = repo.create_session("Fix the flaky test", starting_branch="main")
+        >>> print(repo.list_branches())
+
+    Attributes:
+        source: The resolved Source
+        name: Full resource name, e.g. "sources/github/octo/app"
+    """
+
+    def __init__(self, sources: SourcesAPI, sessions: SessionsAPI, source: Source) -> None:
+        """Initialize the handle.
+
+        Args:
+            sources: Sources API to forward to
+            sessions: Sessions API to forward to
+            source: The resolved source
+        """
+        self.sources = sources
+        self.sessions = sessions
+        self.source = source
+        self.name = source.name
+
+    def __repr__(self) -> str:
+        """Show the bound source."""
+        return f"SourceHandle({self.name})"
+
+    def create_session(self, prompt: str, **kwargs: Any) -> Session:
+        """Create a session on this source.
+
+        Args:
+            prompt: The prompt to start the session with
+            **kwargs: Other options accepted by SessionsAPI.create, such as
+                starting_branch, title or require_plan_approval
+
+        Returns:
+            Created Session object
+        """
+        return self.sessions.create(prompt=prompt, source=self.name, **kwargs)
+
+    def list_sessions(self, max_pages: Optional[int] = DEFAULT_MAX_PAGES) -> List[Session]:
+        """List the sessions started on this source.
+
+        The API has no server-side filter for this, so every session page is
+        fetched and filtered locally.
+
+        Args:
+            max_pages: Safety limit on pages fetched (default: 1000, None for no limit)
+
+        Returns:
+            List of Session objects
+        """
+        matches: List[Session] = []
+        page_token: Optional[str] = None
+        tracker = PageTracker("sessions", max_pages)
+
+        while True:
+            result = self.sessions.list(page_token=page_token)
+            matches.extend(s for s in result["sessions"] if s.source_context.source == self.name)
+
+            page_token = tracker.advance(result.get("nextPageToken"))
+            if not page_token:
+                break
+
+        return matches
+
+    def list_branches(self) -> List[str]:
+        """List the branch names of the source's GitHub repository.
+
+        Returns:
+            Branch display names, freshly fetched; empty for non-GitHub sources
+        """
+        self.source = self.sources.get(self.name)
+        if not self.source.github_repo:
+            return []
+        return [b.display_name for b in self.source.github_repo.branches]
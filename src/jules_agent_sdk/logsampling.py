@@ -0,0 +1,50 @@
+"""Log filter that samples routine request logs while keeping every failure."""
+
+import logging
+import random
+from typing import Callable
+
+
+class SampledRequestFilter(logging.Filter):
+    """Keep all warnings and errors but only a fraction of successful GET logs.
+
+    wait_for_completion polls the session every few seconds, and each poll
+    logs a request and a response record. Attaching this filter keeps those
+    logs useful without flooding them: records at WARNING or above always pass,
+    as do records about other methods, while request and response records of
+    successful GETs pass with probability sample_rate.
+
+    Example:
+        >>> handler = logging.StreamHandler()
+        >>> handler.addFilter(SampledRequestFilter(sample_rate=0.05))
+        >>> logging.getLogger("jules_agent_sdk").addHandler(handler)
+
+    Attributes:
+        sample_rate: Fraction of successful GET records kept, from 0.0 to 1.0
+    """
+
+    def __init__(self, sample_rate: float = 0.1, rand: Callable[[], float] = random.random) -> None:
+        """Initialize the filter.
+
+        Args:
+            sample_rate: Fraction of successful GET records kept (default: 0.1)
+            rand: Source of random numbers in [0, 1), injectable for tests
+
+        Raises:
+            ValueError: If sample_rate is outside 0.0 to 1.0
+        """
+        super().__init__()
+        if not 0.0 <= sample_rate <= 1.0:
+            raise ValueError(f"sample_rate must be between 0 and 1, got {sample_rate}")
+        self.sample_rate = sample_rate
+        self.rand = rand
+
+    def filter(self, record: logging.LogRecord) -> bool:
+        """Decide whether a record is emitted."""
+        if record.levelno >= logging.WARNING:
+            return True
+        if getattr(record, "method", None) != "GET":
+            return True
+        if getattr(record, "status", 200) >= 400:
+            return True
+        return self.rand() < self.sample_rate
@@ -0,0 +1,271 @@
+"""Ordered, retrying outbound message queues for a single session."""
+
+import asyncio
+import logging
+import threading
+import time
+from collections import deque
+from typing import Callable, Deque, Iterable, Optional
+
+from jules_agent_sdk.exceptions import JulesAPIError
+from jules_agent_sdk.protocols import AsyncMessageSender, MessageSender
+
+logger = logging.getLogger(__name__)
+
+# Statuses returned while a session is changing state and cannot take messages yet
+CONFLICT_STATUSES = (400, 409)
+
+
+class MessageQueue:
+    """Send messages to one session in order, retrying transient conflicts.
+
+    Messages sent while a session is switching states are sometimes rejected
+    with 409 or 400. The queue sends from a background thread, one message at
+    a time in the order they were put, and retries a rejected message before
+    moving on. If a message still fails, the messages queued after it are
+    dropped rather than delivered out of context, and the error is raised by
+    the next put() or flush().
+
+    Example:
+        >>> with MessageQueue(client.sessions, "abc123") as queue:
+        ...     queue.put("Part 1: the failing test is test_login")
+        ...     queue.put("Part 2: it only fails on CI")
+
+    Attributes:
+        session_id: The session messages are sent to
+        error: The error that stopped the queue, if any; usually a
+            JulesAPIError, but any exception raised while sending is kept
+    """
+
+    def __init__(
+        self,
+        sessions: MessageSender,
+        session_id: str,
+        max_attempts: int = 5,
+        retry_delay: float = 2.0,
+        retry_statuses: Iterable[int] = CONFLICT_STATUSES,
+        sleep: Callable[[float], None] = time.sleep,
+    ) -> None:
+        """Initialize the queue.
+
+        Args:
+            sessions: Sessions API, or any MessageSender, used to send the messages
+            session_id: The session ID or full name
+            max_attempts: Attempts per message before giving up (default: 5)
+            retry_delay: Seconds between attempts, doubled after each (default: 2.0)
+            retry_statuses: HTTP statuses treated as transient conflicts
+            sleep: Sleep function, injectable for tests
+        """
+        self.sessions = sessions
+        self.session_id = session_id
+        self.max_attempts = max_attempts
+        self.retry_delay = retry_delay
+        self.retry_statuses = frozenset(retry_statuses)
+        self.sleep = sleep
+        self.error: Optional[Exception] = None
+        self._pending: Deque[str] = deque()
+        self._sending = False
+        self._closed = False
+        self._cond = threading.Condition()
+        self._worker: Optional[threading.Thread] = None
+
+    def put(self, prompt: str) -> None:
+        """Queue a message for sending.
+
+        Args:
+            prompt: The message to send
+
+        Raises:
+            JulesAPIError: If an earlier message failed to send (or whatever
+                other error stopped the queue)
+            RuntimeError: If the queue is closed
+        """
+        with self._cond:
+            if self.error is not None:
+                raise self.error
+            if self._closed:
+                raise RuntimeError("MessageQueue is closed")
+            self._pending.append(prompt)
+            if self._worker is None:
+                self._worker = threading.Thread(
+                    target=self._run, name=f"jules-messages-{self.session_id}", daemon=True
+                )
+                self._worker.start()
+            self._cond.notify_all()
+
+    def flush(self, timeout: Optional[float] = None) -> bool:
+        """Wait until every queued message has been sent.
+
+        Args:
+            timeout: Maximum seconds to wait, or None to wait indefinitely
+
+        Returns:
+            True if the queue drained, False on timeout
+
+        Raises:
+            JulesAPIError: If a message failed to send
+        """
+        with self._cond:
+            drained = self._cond.wait_for(
+                lambda: self.error is not None or not (self._pending or self._sending), timeout
+            )
+            if self.error is not None:
+                raise self.error
+            return drained
+
+    def close(self, timeout: Optional[float] = None) -> bool:
+        """Send the remaining messages and stop the worker.
+
+        Args:
+            timeout: Maximum seconds to wait for remaining messages
+
+        Returns:
+            True if the queue drained, False on timeout
+
+        Raises:
+            JulesAPIError: If a message failed to send
+        """
+        try:
+            return self.flush(timeout)
+        finally:
+            with self._cond:
+                self._closed = True
+                self._cond.notify_all()
+
+    def __enter__(self) -> "MessageQueue":
+        """Context manager entry."""
+        return self
+
+    def __exit__(self, *args: object) -> None:
+        """Context manager exit; sends the remaining messages."""
+        self.close()
+
+    def _run(self) -> None:
+        """Worker loop: send queued messages in order."""
+        while True:
+            with self._cond:
+                self._cond.wait_for(lambda: self._pending or self._closed)
+                if not self._pending:
+                    return
+                prompt = self._pending[0]
+                self._sending = True
+
+            try:
+                error: Optional[Exception] = self._send(prompt)
+            except Exception as e:
+                # Anything else stops the queue too, so flush() and close() return
+                error = e
+
+            with self._cond:
+                self._sending = False
+                if error is None:
+                    self._pending.popleft()
+                else:
+                    if len(self._pending) > 1:
+                        logger.warning(
+                            f"Dropping {len(self._pending) - 1} queued messages for "
+                            f"{self.session_id} after a failed send"
+                        )
+                    self._pending.clear()
+                    self.error = error
+                self._cond.notify_all()
+
+    def _send(self, prompt: str) -> Optional[JulesAPIError]:
+        """Send one message with retries; return the final error, if any."""
+        delay = self.retry_delay
+        for attempt in range(1, self.max_attempts + 1):
+            try:
+                self.sessions.send_message(self.session_id, prompt)
+                return None
+            except JulesAPIError as e:
+                if e.status_code not in self.retry_statuses or attempt == self.max_attempts:
+                    return e
+                logger.info(
+                    f"Message to {self.session_id} rejected with {e.status_code} "
+                    f"(attempt {attempt}/{self.max_attempts}); retrying in {delay}s"
+                )
+                self.sleep(delay)
+                delay *= 2
+        return None
+
+
+class AsyncMessageQueue:
+    """Async counterpart of MessageQueue, sending from a background task."""
+
+    def __init__(
+        self,
+        sessions: AsyncMessageSender,
+        session_id: str,
+        max_attempts: int = 5,
+        retry_delay: float = 2.0,
+        retry_statuses: Iterable[int] = CONFLICT_STATUSES,
+    ) -> None:
+        """Initialize the queue; see MessageQueue for the arguments."""
+        self.sessions = sessions
+        self.session_id = session_id
+        self.max_attempts = max_attempts
+        self.retry_delay = retry_delay
+        self.retry_statuses = frozenset(retry_statuses)
+        self.error: Optional[Exception] = None
+        self._queue: "Optional[asyncio.Queue[str]]" = None
+        self._worker: "Optional[asyncio.Task[None]]" = None
+
+    async def put(self, prompt: str) -> None:
+        """Queue a message for sending."""
+        if self.error is not None:
+            raise self.error
+        if self._queue is None:
+            self._queue = asyncio.Queue()
+            self._worker = asyncio.ensure_future(self._run())
+        self._queue.put_nowait(prompt)
+
+    async def flush(self) -> None:
+        """Wait until every queued message has been sent."""
+        if self._queue is not None:
+            await self._queue.join()
+        if self.error is not None:
+            raise self.error
+
+    async def close(self) -> None:
+        """Send the remaining messages and stop the worker."""
+        try:
+            await self.flush()
+        finally:
+            if self._worker is not None:
+                self._worker.cancel()
+
+    async def __aenter__(self) -> "AsyncMessageQueue":
+        """Async context manager entry."""
+        return self
+
+    async def __aexit__(self, *args: object) -> None:
+        """Async context manager exit; sends the remaining messages."""
+        await self.close()
+
+    async def _run(self) -> None:
+        """Worker loop: send queued messages in order."""
+        assert self._queue is not None
+        while True:
+            prompt = await self._queue.get()
+            try:
+                if self.error is None:
+                    self.error = await self._send(prompt)
+            except Exception as e:
+                # Keep the worker alive, so the rest of the queue is marked done
+                self.error = e
+            finally:
+                self._queue.task_done()
+
+    async def _send(self, prompt: str) -> Optional[JulesAPIError]:
+        """Send one message with retries; return the final error, if any."""
+        delay = self.retry_delay
+        for attempt in range(1, self.max_attempts + 1):
+            try:
+                await self.sessions.send_message(self.session_id, prompt)
+                return None
+            except JulesAPIError as e:
+                if e.status_code not in self.retry_statuses or attempt == self.max_attempts:
+                    return e
+                await asyncio.sleep(delay)
+                delay *= 2
+        return None
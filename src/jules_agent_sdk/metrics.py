@@ -0,0 +1,191 @@
+"""Request metrics for the sync client, labelled by route and status class.
+
+get_stats() returns flat totals. Metrics keeps request counts, error counts,
+retries and latency histograms per method, route and status class, and can be
+registered as a Prometheus collector with the optional prometheus-client
+package:
+
+    pip install "jules-agent-sdk[metrics]"
+"""
+
+import bisect
+import threading
+import time
+from dataclasses import dataclass, field
+from typing import Any, Dict, Iterator, List, Optional, Sequence, Tuple
+from urllib.parse import urlsplit
+
+import requests
+
+from jules_agent_sdk.base import Doer
+from jules_agent_sdk.context import current_attempt
+from jules_agent_sdk.resources import route
+
+# Upper bounds in seconds of the latency histogram buckets
+DEFAULT_BUCKETS = (0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0, 10.0, 30.0, 60.0)
+
+# Status class recorded for attempts that failed before a response arrived
+TRANSPORT = "transport"
+
+
+def status_class(status: Optional[int]) -> str:
+    """Return "2xx", "4xx", "5xx" etc. for a status code, or "transport" for None."""
+    return TRANSPORT if status is None else f"{status // 100}xx"
+
+
+def _is_error(cls: str) -> bool:
+    """Return whether a status class counts as an error."""
+    return cls in (TRANSPORT, "4xx", "5xx")
+
+
+@dataclass
+class Histogram:
+    """Latency histogram with fixed bucket upper bounds."""
+
+    buckets: Sequence[float]
+    counts: List[int] = field(default_factory=list)
+    total: float = 0.0
+    count: int = 0
+
+    def __post_init__(self) -> None:
+        """Create one count per bucket plus the +Inf bucket."""
+        self.counts = [0] * (len(self.buckets) + 1)
+
+    def observe(self, value: float) -> None:
+        """Record one observation."""
+        self.counts[bisect.bisect_left(self.buckets, value)] += 1
+        self.total += value
+        self.count += 1
+
+    def cumulative(self) -> List[Tuple[str, int]]:
+        """Return (upper bound, cumulative count) pairs in Prometheus order."""
+        pairs, running = [], 0
+        for bound, count in zip([*map(str, self.buckets), "+Inf"], self.counts):
+            running += count
+            pairs.append((bound, running))
+        return pairs
+
+
+class Metrics:
+    """Collects per-attempt request metrics through the client middleware chain.
+
+    Requests and errors are labelled (method, route, status_class), where
+    route has IDs replaced by {id} to keep cardinality bounded. Every attempt
+    is counted, and attempts after the first are also counted as retries.
+
+    Example:
+        >>> metrics = Metrics()
+        >>> client = JulesClient(api_key=key, middlewares=[metrics.middleware])
+        >>> prometheus_client.REGISTRY.register(metrics)
+    """
+
+    def __init__(self, buckets: Sequence[float] = DEFAULT_BUCKETS, prefix: str = "jules_client"):
+        """Initialize empty metrics.
+
+        Args:
+            buckets: Upper bounds in seconds of the latency histogram buckets
+            prefix: Prefix of the exported Prometheus metric names
+        """
+        self.buckets = tuple(sorted(buckets))
+        self.prefix = prefix
+        self.requests: Dict[Tuple[str, str, str], int] = {}
+        self.retries: Dict[Tuple[str, str], int] = {}
+        self.latency: Dict[Tuple[str, str], Histogram] = {}
+        self._lock = threading.Lock()
+
+    def middleware(self, next_doer: Doer) -> Doer:
+        """Middleware recording every attempt; pass the bound method to the client."""
+
+        def doer(method: str, url: str, **kwargs: Any) -> requests.Response:
+            start = time.monotonic()
+            status: Optional[int] = None
+            try:
+                response = next_doer(method=method, url=url, **kwargs)
+                status = response.status_code
+                return response
+            finally:
+                self.observe(
+                    method, urlsplit(url).path, status, time.monotonic() - start, current_attempt()
+                )
+
+        return doer
+
+    def observe(
+        self,
+        method: str,
+        path: str,
+        status: Optional[int],
+        seconds: float,
+        attempt: Optional[int] = None,
+    ) -> None:
+        """Record one HTTP attempt.
+
+        Args:
+            method: HTTP method
+            path: Request path; IDs are replaced with {id}
+            status: Response status code, or None if the attempt failed in transport
+            seconds: Time the attempt took
+            attempt: 1-based attempt number; attempts after the first count as retries
+        """
+        key = (method, route(path))
+        with self._lock:
+            labels = (*key, status_class(status))
+            self.requests[labels] = self.requests.get(labels, 0) + 1
+            if attempt is not None and attempt > 1:
+                self.retries[key] = self.retries.get(key, 0) + 1
+            if key not in self.latency:
+                self.latency[key] = Histogram(self.buckets)
+            self.latency[key].observe(seconds)
+
+    def errors(self) -> Dict[str, int]:
+        """Return error counts by status class, e.g. {"4xx": 2, "transport": 1}."""
+        counts: Dict[str, int] = {}
+        with self._lock:
+            for (_, _, cls), count in self.requests.items():
+                if _is_error(cls):
+                    counts[cls] = counts.get(cls, 0) + count
+        return counts
+
+    def collect(self) -> Iterator[Any]:
+        """Yield Prometheus metric families; called by a prometheus_client registry.
+
+        Raises:
+            ImportError: If prometheus-client is not installed
+        """
+        try:
+            from prometheus_client.core import CounterMetricFamily, HistogramMetricFamily
+        except ImportError as e:
+            raise ImportError(
+                "Metrics.collect requires prometheus-client; "
+                'install it with pip install "jules-agent-sdk[metrics]"'
+            ) from e
+
+        labels = ["method", "route", "status_class"]
+        requests_total = CounterMetricFamily(
+            f"{self.prefix}_requests", "HTTP attempts made to the Jules API", labels=labels
+        )
+        errors_total = CounterMetricFamily(
+            f"{self.prefix}_errors",
+            "HTTP attempts that returned an error status or failed in transport",
+            labels=labels,
+        )
+        retries_total = CounterMetricFamily(
+            f"{self.prefix}_retries", "HTTP attempts that were retries", labels=labels[:2]
+        )
+        duration = HistogramMetricFamily(
+            f"{self.prefix}_request_duration_seconds",
+            "Duration of HTTP attempts to the Jules API",
+            labels=labels[:2],
+        )
+
+        with self._lock:
+            for key, count in sorted(self.requests.items()):
+                requests_total.add_metric(list(key), count)
+                if _is_error(key[2]):
+                    errors_total.add_metric(list(key), count)
+            for key, count in sorted(self.retries.items()):
+                retries_total.add_metric(list(key), count)
+            for key, histogram in sorted(self.latency.items()):
+                duration.add_metric(list(key), histogram.cumulative(), histogram.total)
+
+        yield from (requests_total, errors_total, retries_total, duration)
@@ -1,9 +1,49 @@
 """Data models for Jules API resources."""
 
+import re
 from dataclasses import dataclass, field
-from typing import Optional, List, Dict, Any
+from datetime import datetime
+from typing import Optional, List, Dict, Any, Iterator, Union
 from enum import Enum
 
+from jules_agent_sdk.diff import Hunk, iter_hunks
+from jules_agent_sdk.extensions import decode_activity_event, registered_activity_kinds
+
+# Event keys an activity can carry that have a field on Activity
+ACTIVITY_EVENT_KEYS = (
+    "agentMessaged",
+    "userMessaged",
+    "planGenerated",
+    "planApproved",
+    "progressUpdated",
+    "sessionCompleted",
+    "sessionFailed",
+)
+
+_ACTIVITY_KEYS = frozenset(
+    ACTIVITY_EVENT_KEYS + ("name", "id", "description", "createTime", "originator", "artifacts")
+)
+
+_FRACTION = re.compile(r"\.(\d+)")
+
+
+def parse_timestamp(value: str) -> Optional[datetime]:
+    """Parse an API timestamp; fractions beyond microseconds are dropped.
+
+    Args:
+        value: RFC 3339 timestamp, e.g. an Activity's create_time
+
+    Returns:
+        A timezone-aware datetime, or None if the value is empty or malformed
+    """
+    if not value:
+        return None
+    value = _FRACTION.sub(lambda m: "." + m.group(1)[:6].ljust(6, "0"), value, count=1)
+    try:
+        return datetime.fromisoformat(value.replace("Z", "+00:00"))
+    except ValueError:
+        return None
+
 
 class SessionState(str, Enum):
     """Session state enumeration."""
@@ -18,6 +58,15 @@ class SessionState(str, Enum):
     FAILED = "FAILED"
     COMPLETED = "COMPLETED"
 
+    def __str__(self) -> str:
+        """Return the bare state name, e.g. ``IN_PROGRESS``."""
+        return self.value
+
+
+def _snake(key: str) -> str:
+    """Convert an API key to its model field name, e.g. planGenerated."""
+    return "".join(f"_{c.lower()}" if c.isupper() else c for c in key)
+
 
 @dataclass
 class GitHubBranch:
@@ -26,8 +75,14 @@ class GitHubBranch:
     display_name: str
 
     @classmethod
-    def from_dict(cls, data: Dict[str, Any]) -> "GitHubBranch":
-        """Create from API response dictionary."""
+    def from_dict(cls, data: Union[str, Dict[str, Any]]) -> "GitHubBranch":
+        """Create from API response data.
+
+        The API has returned branches both as objects ({"displayName": "main"})
+        and as bare strings ("main"); both are accepted.
+        """
+        if isinstance(data, str):
+            return cls(display_name=data)
         return cls(display_name=data.get("displayName", ""))
 
     def to_dict(self) -> Dict[str, Any]:
@@ -106,6 +161,13 @@ class Source:
             result["githubRepo"] = self.github_repo.to_dict()
         return result
 
+    @property
+    def default_branch_name(self) -> str:
+        """Name of the repository's default branch, or "" if unknown."""
+        if self.github_repo and self.github_repo.default_branch:
+            return self.github_repo.default_branch.display_name
+        return ""
+
 
 @dataclass
 class GitHubRepoContext:
@@ -206,6 +268,8 @@ class Session:
     state: SessionState = SessionState.STATE_UNSPECIFIED
     url: str = ""
     outputs: List[SessionOutput] = field(default_factory=list)
+    # Idempotency key the session was created with; set by create(), not sent by the API
+    idempotency_key: str = field(default="", compare=False)
 
     @classmethod
     def from_dict(cls, data: Dict[str, Any]) -> "Session":
@@ -252,6 +316,28 @@ class Session:
             result["outputs"] = [o.to_dict() for o in self.outputs]
         return result
 
+    @property
+    def starting_branch(self) -> str:
+        """Branch the session started from, or "" if not set."""
+        if self.source_context and self.source_context.github_repo_context:
+            return self.source_context.github_repo_context.starting_branch
+        return ""
+
+    @property
+    def pull_request(self) -> Optional[PullRequest]:
+        """The first pull request among the session's outputs, if any."""
+        for output in self.outputs:
+            if output.pull_request:
+                return output.pull_request
+        return None
+
+    def __str__(self) -> str:
+        """Return a short, stable description for logs."""
+        label = self.name or self.id or "<unsaved>"
+        if self.title:
+            return f"Session({label}, {self.state}, title={self.title!r})"
+        return f"Session({label}, {self.state})"
+
 
 @dataclass
 class PlanStep:
@@ -303,11 +389,55 @@ class Plan:
 
     def to_dict(self) -> Dict[str, Any]:
         """Convert to API request dictionary."""
-        return {
-            "id": self.id,
-            "steps": [s.to_dict() for s in self.steps],
-            "createTime": self.create_time,
-        }
+        result: Dict[str, Any] = {"id": self.id, "steps": [s.to_dict() for s in self.steps]}
+        if self.create_time:
+            result["createTime"] = self.create_time
+        return result
+
+    def __str__(self) -> str:
+        """Return a short, stable description for logs."""
+        return f"Plan({self.id}, {len(self.steps)} steps)"
+
+
+@dataclass
+class ProgressUpdate:
+    """A progress update posted by the agent while working through its plan."""
+
+    title: str = ""
+    description: str = ""
+    step_index: Optional[int] = None
+
+    @classmethod
+    def from_dict(cls, data: Dict[str, Any]) -> "ProgressUpdate":
+        """Create from API response dictionary."""
+        return cls(title=data.get("title", ""), description=data.get("description", ""))
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Convert to API request dictionary."""
+        return {"title": self.title, "description": self.description}
+
+    def correlate(self, plan: Plan) -> "ProgressUpdate":
+        """Match this update to the plan step it reports on.
+
+        The API does not link updates to steps, so steps are matched by title,
+        ignoring case; an update whose title contains a step title also matches.
+
+        Args:
+            plan: The plan the agent is executing
+
+        Returns:
+            A copy of this update with step_index set to the zero-based index of
+            the matching step, or None if no step matches
+        """
+        title = self.title.strip().lower()
+        index = None
+        for step in plan.steps:
+            step_title = step.title.strip().lower()
+            if step_title and (title == step_title or step_title in title):
+                index = step.index
+                if title == step_title:
+                    break
+        return ProgressUpdate(self.title, self.description, index)
 
 
 @dataclass
@@ -335,6 +465,10 @@ class GitPatch:
             "suggestedCommitMessage": self.suggested_commit_message,
         }
 
+    def hunks(self) -> Iterator[Hunk]:
+        """Iterate over the hunks of the patch without materializing them all."""
+        return iter_hunks(self.unidiff_patch)
+
 
 @dataclass
 class ChangeSet:
@@ -433,6 +567,11 @@ class Artifact:
             result["bashOutput"] = self.bash_output.to_dict()
         return result
 
+    @property
+    def git_patch(self) -> Optional[GitPatch]:
+        """The patch of a change set artifact, if any."""
+        return self.change_set.git_patch if self.change_set else None
+
 
 @dataclass
 class Activity:
@@ -451,6 +590,8 @@ class Activity:
     progress_updated: Optional[Dict[str, str]] = None
     session_completed: Optional[Dict[str, Any]] = None
     session_failed: Optional[Dict[str, str]] = None
+    # Raw payloads of fields the SDK does not model, e.g. newer event kinds
+    extensions: Dict[str, Any] = field(default_factory=dict)
 
     @classmethod
     def from_dict(cls, data: Dict[str, Any]) -> "Activity":
@@ -473,8 +614,14 @@ class Activity:
             progress_updated=data.get("progressUpdated"),
             session_completed=data.get("sessionCompleted"),
             session_failed=data.get("sessionFailed"),
+            extensions={k: v for k, v in data.items() if k not in _ACTIVITY_KEYS},
         )
 
+    @classmethod
+    def is_extension_key(cls, key: str) -> bool:
+        """Whether key is an event kind registered with register_activity_kind."""
+        return key in registered_activity_kinds()
+
     def to_dict(self) -> Dict[str, Any]:
         """Convert to API request dictionary."""
         result: Dict[str, Any] = {"name": self.name}
@@ -485,3 +632,188 @@ class Activity:
         if self.artifacts:
             result["artifacts"] = [a.to_dict() for a in self.artifacts]
         return result
+
+    @property
+    def plan(self) -> Optional[Plan]:
+        """The plan of a planGenerated event, or None for other activities."""
+        if not self.plan_generated or not self.plan_generated.get("plan"):
+            return None
+        return Plan.from_dict(self.plan_generated["plan"])
+
+    @property
+    def progress(self) -> Optional[ProgressUpdate]:
+        """The update of a progressUpdated event, or None for other activities."""
+        if self.progress_updated is None:
+            return None
+        return ProgressUpdate.from_dict(self.progress_updated)
+
+    @property
+    def agent_message(self) -> str:
+        """Text of an agentMessaged event, or "" for other activities."""
+        return (self.agent_messaged or {}).get("agentMessage", "")
+
+    @property
+    def user_message(self) -> str:
+        """Text of a userMessaged event, or "" for other activities."""
+        return (self.user_messaged or {}).get("userMessage", "")
+
+    @property
+    def failure_reason(self) -> str:
+        """Reason of a sessionFailed event, or "" for other activities."""
+        return (self.session_failed or {}).get("reason", "")
+
+    def event(self, key: str) -> Any:
+        """The event payload for an API key, decoded if a decoder is registered.
+
+        Args:
+            key: Event key, e.g. "toolInvoked" or "agentMessaged"
+
+        Returns:
+            The decoded event, the raw payload if no decoder is registered, or
+            None if the activity does not carry that event
+        """
+        if key in ACTIVITY_EVENT_KEYS:
+            return getattr(self, _snake(key))
+        if key not in self.extensions:
+            return None
+        return decode_activity_event(key, self.extensions[key])
+
+    @property
+    def kind(self) -> str:
+        """The API key of the event this activity carries, e.g. ``agentMessaged``.
+
+        Events of unknown kinds are only reported once registered with
+        register_activity_kind.
+        """
+        events = (
+            ("agentMessaged", self.agent_messaged),
+            ("userMessaged", self.user_messaged),
+            ("planGenerated", self.plan_generated),
+            ("planApproved", self.plan_approved),
+            ("progressUpdated", self.progress_updated),
+            ("sessionCompleted", self.session_completed),
+            ("sessionFailed", self.session_failed),
+        )
+        for key, value in events:
+            if value is not None:
+                return key
+        registered = registered_activity_kinds()
+        for key in self.extensions:
+            if key in registered:
+                return key
+        return ""
+
+    def __str__(self) -> str:
+        """Return a short, stable description for logs."""
+        parts = [self.name or self.id or "<unnamed>"]
+        if self.kind:
+            parts.append(self.kind)
+        if self.description:
+            parts.append(repr(self.description))
+        return f"Activity({', '.join(parts)})"
+
+
+@dataclass
+class CompletionDetails:
+    """The outcome of a completed session, gathered from the session and its activities."""
+
+    session: Session
+    completed_time: str = ""
+    summary: str = ""
+    pull_requests: List[PullRequest] = field(default_factory=list)
+    change_sets: List[ChangeSet] = field(default_factory=list)
+    stats: Dict[str, Any] = field(default_factory=dict)
+
+    @classmethod
+    def from_session(cls, session: Session, activities: List[Activity]) -> "CompletionDetails":
+        """Build completion details from a session and its activities.
+
+        Args:
+            session: The session, normally in the COMPLETED state
+            activities: All activities of the session
+
+        Returns:
+            CompletionDetails; fields other than session are empty if the
+            session has no sessionCompleted activity yet
+        """
+        pull_requests = [o.pull_request for o in session.outputs if o.pull_request]
+
+        completed = [a for a in activities if a.session_completed is not None]
+        if not completed:
+            return cls(session=session, pull_requests=pull_requests)
+        completion = completed[-1]
+
+        summary = completion.description
+        if not summary:
+            for activity in reversed(activities):
+                if activity.agent_messaged:
+                    summary = activity.agent_messaged.get("agentMessage", "")
+                    break
+
+        return cls(
+            session=session,
+            completed_time=completion.create_time,
+            summary=summary,
+            pull_requests=pull_requests,
+            change_sets=[a.change_set for a in completion.artifacts if a.change_set],
+            stats=dict(completion.session_completed or {}),
+        )
+
+
+@dataclass
+class PlanProgress:
+    """How far a session has got through its plan, e.g. for progress bars."""
+
+    plan: Plan
+    completed_steps: int = 0
+    latest: Optional[ProgressUpdate] = None
+
+    @property
+    def total_steps(self) -> int:
+        """Number of steps in the plan."""
+        return len(self.plan.steps)
+
+    @property
+    def fraction(self) -> float:
+        """Completed share of the plan, from 0.0 to 1.0."""
+        if not self.total_steps:
+            return 0.0
+        return min(self.completed_steps / self.total_steps, 1.0)
+
+    @classmethod
+    def from_activities(cls, activities: List[Activity]) -> Optional["PlanProgress"]:
+        """Correlate a session's progress updates with its plan.
+
+        The approved plan is used if there is one, otherwise the latest
+        generated plan. A step counts as completed once a progress update for
+        it or a later step has been posted.
+
+        Args:
+            activities: Activities of the session, oldest first
+
+        Returns:
+            PlanProgress, or None if no plan has been generated yet
+        """
+        plans = [a.plan for a in activities if a.plan is not None]
+        if not plans:
+            return None
+
+        plan = plans[-1]
+        approved = [a.plan_approved.get("planId") for a in activities if a.plan_approved]
+        if approved:
+            plan = next((p for p in plans if p.id == approved[-1]), plan)
+
+        progress = cls(plan=plan)
+        for activity in activities:
+            update = activity.progress
+            if update is None:
+                continue
+            progress.latest = update.correlate(plan)
+            if progress.latest.step_index is not None:
+                completed = progress.latest.step_index + 1
+                progress.completed_steps = max(progress.completed_steps, completed)
+        return progress
+
+    def __str__(self) -> str:
+        """Return a short description such as "3/7 steps"."""
+        return f"{self.completed_steps}/{self.total_steps} steps"
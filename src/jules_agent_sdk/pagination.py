@@ -0,0 +1,122 @@
+"""Shared pagination helpers for list endpoints."""
+
+import logging
+from typing import Any, Dict, Optional, Set
+
+from jules_agent_sdk.exceptions import JulesValidationError, PaginationLoopError
+
+logger = logging.getLogger(__name__)
+
+# Largest page size the Jules API accepts for list endpoints
+MAX_PAGE_SIZE = 100
+
+# Page limit for list_all loops; callers pass max_pages=None to lift it
+DEFAULT_MAX_PAGES = 1000
+
+
+def resolve_page_size(page_size: Optional[int], default_page_size: Optional[int]) -> Optional[int]:
+    """Pick the page size to request, clamping it to the API maximum.
+
+    Args:
+        page_size: Page size requested for this call, if any
+        default_page_size: Client-level default, if any
+
+    Returns:
+        The page size to send, or None to use the server default
+
+    Raises:
+        JulesValidationError: If the page size is not a non-negative integer
+    """
+    size = page_size if page_size is not None else default_page_size
+    if size is None:
+        return None
+    if isinstance(size, bool) or not isinstance(size, int) or size < 0:
+        raise JulesValidationError(f"Page size must be a non-negative integer, got {size!r}")
+    if size > MAX_PAGE_SIZE:
+        logger.warning(f"Page size {size} exceeds the API maximum; using {MAX_PAGE_SIZE}")
+        size = MAX_PAGE_SIZE
+    return size
+
+
+def list_params(
+    page_size: Optional[int],
+    page_token: Optional[str],
+    default_page_size: Optional[int],
+    filter_str: Optional[str] = None,
+) -> Dict[str, Any]:
+    """Build the query parameters shared by every list endpoint.
+
+    Args:
+        page_size: Page size requested for this call, if any
+        page_token: Token from a previous page's nextPageToken, if any
+        default_page_size: Client-level default page size, if any
+        filter_str: Filter expression, for endpoints that support one
+
+    Returns:
+        Query parameters for the request
+
+    Raises:
+        JulesValidationError: If the page size or page token is invalid
+    """
+    params: Dict[str, Any] = {}
+    if filter_str:
+        params["filter"] = filter_str
+    size = resolve_page_size(page_size, default_page_size)
+    if size is not None:
+        params["pageSize"] = size
+    if page_token:
+        if not isinstance(page_token, str) or page_token.split() != [page_token]:
+            raise JulesValidationError(
+                f"Invalid page token {page_token!r}; pass nextPageToken back unchanged"
+            )
+        params["pageToken"] = page_token
+    return params
+
+
+class PageTracker:
+    """Guards a list_all loop against runaway pagination."""
+
+    def __init__(self, resource: str, max_pages: Optional[int] = DEFAULT_MAX_PAGES) -> None:
+        """Initialize the tracker.
+
+        Args:
+            resource: What is being listed, used in error messages
+            max_pages: Maximum pages to fetch, or None for no limit
+        """
+        self.resource = resource
+        self.max_pages = max_pages
+        self.pages = 0
+        self._seen: Set[str] = set()
+
+    def advance(self, next_page_token: Optional[str]) -> Optional[str]:
+        """Record a fetched page and validate the token for the next one.
+
+        Args:
+            next_page_token: Token returned with the page just fetched
+
+        Returns:
+            The token to request next, or None when listing is complete
+
+        Raises:
+            PaginationLoopError: If the token repeats or the page limit is reached
+        """
+        self.pages += 1
+        if not next_page_token:
+            return None
+
+        if next_page_token in self._seen:
+            raise PaginationLoopError(
+                f"Listing {self.resource} returned page token {next_page_token!r} twice",
+                self.pages,
+                next_page_token,
+            )
+        if self.max_pages is not None and self.pages >= self.max_pages:
+            raise PaginationLoopError(
+                f"Listing {self.resource} exceeded {self.max_pages} pages; "
+                "pass max_pages=None to list without a limit",
+                self.pages,
+                next_page_token,
+            )
+
+        self._seen.add(next_page_token)
+        return next_page_token
@@ -0,0 +1,166 @@
+"""Client-side prompt size estimation, fitting and processing helpers."""
+
+import re
+from typing import Callable, Iterable, List
+
+from jules_agent_sdk.exceptions import PromptTooLargeError
+
+# Rough average for English prose; source code and logs usually tokenize denser
+CHARS_PER_TOKEN = 4
+
+TRUNCATION_MARKER = "\n\n[... truncated ...]\n\n"
+
+# Rewrites an outgoing prompt or message, e.g. to normalize or scrub it.
+PromptProcessor = Callable[[str], str]
+
+_EMAIL = re.compile(r"[\w.+-]+@[\w-]+(\.[\w-]+)+")
+
+
+def estimate_tokens(text: str) -> int:
+    """Estimate the number of tokens in a prompt.
+
+    Args:
+        text: Prompt text
+
+    Returns:
+        Approximate token count
+
+    Example:
+        >>> estimate_tokens("Fix the login bug")
+        5
+    """
+    return -(-len(text) // CHARS_PER_TOKEN)
+
+
+def check_prompt(text: str, max_tokens: int) -> None:
+    """Raise if a prompt is estimated to exceed a token limit.
+
+    Args:
+        text: Prompt text
+        max_tokens: Maximum allowed tokens
+
+    Raises:
+        PromptTooLargeError: If the estimate exceeds max_tokens
+    """
+    estimated = estimate_tokens(text)
+    if estimated > max_tokens:
+        raise PromptTooLargeError(estimated, max_tokens)
+
+
+def apply_processors(text: str, processors: Iterable[PromptProcessor]) -> str:
+    """Run a prompt through processors in order.
+
+    Args:
+        text: Prompt text
+        processors: Processors to apply
+
+    Returns:
+        The processed prompt
+    """
+    for processor in processors:
+        text = processor(text)
+    return text
+
+
+def normalize_whitespace(text: str) -> str:
+    """Strip trailing spaces and runs of blank lines, keeping paragraph breaks.
+
+    A prompt processor for prompts assembled from templates and logs.
+
+    Example:
+        >>> normalize_whitespace("Fix it.  \n\n\n\nThanks\n")
+        'Fix it.\n\nThanks'
+    """
+    lines = [line.rstrip() for line in text.strip().splitlines()]
+    return re.sub(r"\n{3,}", "\n\n", "\n".join(lines))
+
+
+def scrub_emails(text: str) -> str:
+    """Replace email addresses with a placeholder.
+
+    A prompt processor for prompts built from tickets or logs that may carry
+    personal data.
+
+    Example:
+        >>> scrub_emails("Reported by jane@example.com")
+        'Reported by [email]'
+    """
+    return _EMAIL.sub("[email]", text)
+
+
+def truncate_prompt(text: str, max_tokens: int, marker: str = TRUNCATION_MARKER) -> str:
+    """Shrink a prompt to fit a token limit, keeping its beginning and end.
+
+    The middle is dropped because composed prompts usually carry the task
+    description first and the most relevant log output last.
+
+    Args:
+        text: Prompt text
+        max_tokens: Maximum allowed tokens
+        marker: Text inserted where content was removed
+
+    Returns:
+        The prompt unchanged if it fits, otherwise a truncated copy
+    """
+    max_chars = max_tokens * CHARS_PER_TOKEN
+    if len(text) <= max_chars:
+        return text
+
+    budget = max_chars - len(marker)
+    if budget <= 0:
+        return text[:max_chars]
+
+    head = budget // 2
+    tail = budget - head
+    return text[:head] + marker + (text[-tail:] if tail else "")
+
+
+def chunk_prompt(text: str, max_tokens: int) -> List[str]:
+    """Split a prompt into pieces that each fit a token limit.
+
+    Splits on paragraph boundaries where possible, then on lines, and only
+    cuts inside a line when a single line is too long on its own. Chunks can be
+    sent as a create prompt followed by send_message() calls.
+
+    Args:
+        text: Prompt text
+        max_tokens: Maximum tokens per chunk
+
+    Returns:
+        List of chunks in their original order
+
+    Raises:
+        ValueError: If max_tokens is not positive
+    """
+    if max_tokens <= 0:
+        raise ValueError("max_tokens must be positive")
+
+    max_chars = max_tokens * CHARS_PER_TOKEN
+    chunks: List[str] = []
+    current = ""
+
+    for piece in _split_units(text, max_chars):
+        if current and len(current) + len(piece) > max_chars:
+            chunks.append(current)
+            current = ""
+        current += piece
+
+    if current:
+        chunks.append(current)
+    return chunks
+
+
+def _split_units(text: str, max_chars: int) -> List[str]:
+    """Break text into paragraphs, lines or hard slices no longer than max_chars."""
+    units: List[str] = []
+    for paragraph in re.split(r"(?<=\n\n)", text):
+        if len(paragraph) <= max_chars:
+            units.append(paragraph)
+            continue
+        for line in paragraph.splitlines(keepends=True):
+            if len(line) <= max_chars:
+                units.append(line)
+                continue
+            for start in range(0, len(line), max_chars):
+                units.append(line[start : start + max_chars])
+    return [u for u in units if u]
@@ -0,0 +1,90 @@
+"""Single-operation interfaces accepted by the higher-level helpers.
+
+Helpers such as MessageQueue only need one or two operations of the API
+objects. Typing them against these protocols instead of SessionsAPI or
+ActivitiesAPI lets tests pass tiny fakes that implement just that method.
+
+Example:
+    >>> class FakeSender:
+    ...     def __init__(self):
+    ...         self.sent = []
+    ...     def send_message(self, session_id, prompt):
+    ...         self.sent.append(prompt)
+    >>> queue = MessageQueue(FakeSender(), "abc123")
+"""
+
+from typing import List, Optional, Protocol, runtime_checkable
+
+from jules_agent_sdk.models import Activity, Session
+
+
+@runtime_checkable
+class SessionGetter(Protocol):
+    """Fetches a session, like SessionsAPI.get."""
+
+    def get(self, session_id: str) -> Session:
+        """Get a session by ID or full name."""
+        ...
+
+
+@runtime_checkable
+class PlanApprover(Protocol):
+    """Approves a session's plan, like SessionsAPI.approve_plan."""
+
+    def approve_plan(self, session_id: str, plan_id: Optional[str] = None) -> None:
+        """Approve the pending plan of a session."""
+        ...
+
+
+@runtime_checkable
+class MessageSender(Protocol):
+    """Sends user messages, like SessionsAPI.send_message."""
+
+    def send_message(self, session_id: str, prompt: str) -> None:
+        """Send a message from the user to a session."""
+        ...
+
+
+@runtime_checkable
+class ActivityLister(Protocol):
+    """Lists a session's activities, like ActivitiesAPI.list_all."""
+
+    def list_all(self, session_id: str) -> List[Activity]:
+        """List all activities of a session."""
+        ...
+
+
+@runtime_checkable
+class AsyncSessionGetter(Protocol):
+    """Async counterpart of SessionGetter."""
+
+    async def get(self, session_id: str) -> Session:
+        """Get a session by ID or full name asynchronously."""
+        ...
+
+
+@runtime_checkable
+class AsyncPlanApprover(Protocol):
+    """Async counterpart of PlanApprover."""
+
+    async def approve_plan(self, session_id: str, plan_id: Optional[str] = None) -> None:
+        """Approve the pending plan of a session asynchronously."""
+        ...
+
+
+@runtime_checkable
+class AsyncMessageSender(Protocol):
+    """Async counterpart of MessageSender."""
+
+    async def send_message(self, session_id: str, prompt: str) -> None:
+        """Send a message from the user to a session asynchronously."""
+        ...
+
+
+@runtime_checkable
+class AsyncActivityLister(Protocol):
+    """Async counterpart of ActivityLister."""
+
+    async def list_all(self, session_id: str) -> List[Activity]:
+        """List all activities of a session asynchronously."""
+        ...
@@ -0,0 +1,190 @@
+"""Helpers for turning Jules web URLs, resource names and IDs into API paths."""
+
+import re
+from typing import List, Tuple
+from urllib.parse import urlparse
+
+from jules_agent_sdk.exceptions import InvalidResourceNameError
+
+# Hosts serving the Jules web UI
+JULES_WEB_HOSTS = ("jules.google.com",)
+
+# One path segment of a resource name. Colons are excluded because they would
+# turn an ID into a custom method call such as ":approvePlan".
+_SEGMENT = re.compile(r"^[^/\s?#:]+$")
+
+# Collections whose next path segment is an ID. Source IDs contain slashes,
+# so everything after "sources" is one ID.
+_COLLECTIONS = ("sessions", "activities", "sources")
+
+
+def parse_session_url(url: str) -> str:
+    """Extract the session ID from a Jules web UI URL.
+
+    Query strings, fragments and trailing path segments are ignored, so links
+    pasted from chat or a browser address bar work as-is.
+
+    Args:
+        url: URL such as "https://jules.google.com/session/123456"
+
+    Returns:
+        The session ID, usable with client.sessions.get() and friends
+
+    Raises:
+        InvalidResourceNameError: If the URL is not a Jules session link
+
+    Example:
+        >>> parse_session_url("https://jules.google.com/session/123456?tab=plan")
+        '123456'
+    """
+    parsed = urlparse(url.strip())
+    if parsed.scheme not in ("http", "https") or parsed.hostname not in JULES_WEB_HOSTS:
+        raise InvalidResourceNameError(f"Not a Jules URL: {url!r}", url)
+
+    segments = [s for s in parsed.path.split("/") if s]
+    if len(segments) < 2 or segments[0] not in ("session", "sessions"):
+        raise InvalidResourceNameError(f"Not a Jules session URL: {url!r}", url)
+    return segments[1]
+
+
+def parse_resource_name(name: str) -> Tuple[str, str]:
+    """Split a full resource name into its collection and ID.
+
+    Only the first path segment is treated as the collection, so source IDs
+    that contain slashes are returned intact.
+
+    Args:
+        name: Resource name such as "sessions/123456" or "sources/github/owner/repo"
+
+    Returns:
+        Tuple of (collection, id)
+
+    Raises:
+        InvalidResourceNameError: If name has no collection prefix or an empty ID
+
+    Example:
+        >>> parse_resource_name("sources/github/octo/app")
+        ('sources', 'github/octo/app')
+    """
+    collection, sep, resource_id = name.strip().partition("/")
+    if not sep or not collection or not resource_id:
+        raise InvalidResourceNameError(f"Not a resource name: {name!r}", name)
+    return collection, resource_id
+
+
+def _check_input(value: str, kind: str, strict: bool) -> str:
+    """Reject empty input and, in strict mode, surrounding whitespace."""
+    cleaned = value.strip()
+    if not cleaned:
+        raise InvalidResourceNameError(f"{kind} is required", value)
+    if strict and cleaned != value:
+        raise InvalidResourceNameError(f"{kind} has surrounding whitespace: {value!r}", value)
+    return cleaned
+
+
+def session_path(session_id: str, strict: bool = False) -> str:
+    """Build the resource name for a session.
+
+    Accepts a bare ID ("abc123") or a full name ("sessions/abc123"). Lenient
+    mode also strips whitespace and accepts Jules web UI URLs; strict mode
+    rejects both so that pasted values never reach the API unexamined.
+
+    Args:
+        session_id: Session ID, full name or (lenient mode only) URL
+        strict: Reject URLs and surrounding whitespace
+
+    Returns:
+        The session resource name, e.g. "sessions/abc123"
+
+    Raises:
+        InvalidResourceNameError: If the input does not identify a single session
+
+    Example:
+        >>> session_path("https://jules.google.com/session/123456")
+        'sessions/123456'
+    """
+    value = _check_input(session_id, "Session ID", strict)
+
+    if "://" in value:
+        if strict:
+            raise InvalidResourceNameError(
+                f"Expected a session ID, got a URL: {value!r}; use parse_session_url()",
+                session_id,
+            )
+        value = parse_session_url(value)
+
+    if value.startswith("sessions/"):
+        value = value[len("sessions/") :]
+    if not _SEGMENT.match(value):
+        raise InvalidResourceNameError(f"Invalid session ID: {session_id!r}", session_id)
+    return f"sessions/{value}"
+
+
+def activity_path(session_id: str, activity_id: str, strict: bool = False) -> str:
+    """Build the resource name for an activity within a session.
+
+    Args:
+        session_id: Session ID, full name or (lenient mode only) URL
+        activity_id: Activity ID
+        strict: Reject URLs and surrounding whitespace
+
+    Returns:
+        The activity resource name, e.g. "sessions/abc123/activities/act1"
+
+    Raises:
+        InvalidResourceNameError: If either ID is malformed
+    """
+    value = _check_input(activity_id, "Activity ID", strict)
+    if not _SEGMENT.match(value):
+        raise InvalidResourceNameError(f"Invalid activity ID: {activity_id!r}", activity_id)
+    return f"{session_path(session_id, strict)}/activities/{value}"
+
+
+def source_path(source_id: str, strict: bool = False) -> str:
+    """Build the resource name for a source.
+
+    Source IDs contain slashes ("github/owner/repo"), so every segment is
+    validated rather than the ID as a whole.
+
+    Args:
+        source_id: Source ID or full name (e.g., "github/owner/repo" or
+            "sources/github/owner/repo")
+        strict: Reject surrounding whitespace
+
+    Returns:
+        The source resource name
+
+    Raises:
+        InvalidResourceNameError: If the input does not identify a single source
+    """
+    value = _check_input(source_id, "Source ID", strict)
+
+    if value.startswith("sources/"):
+        value = value[len("sources/") :]
+    segments = value.split("/")
+    if segments[0] == "sources" or not all(_SEGMENT.match(s) for s in segments):
+        raise InvalidResourceNameError(f"Invalid source ID: {source_id!r}", source_id)
+    return f"sources/{value}"
+
+
+def route(path: str) -> str:
+    """Replace the resource IDs in an API path with {id}.
+
+    Example:
+        >>> route("/v1alpha/sessions/123/activities/a1")
+        '/v1alpha/sessions/{id}/activities/{id}'
+        >>> route("/v1alpha/sessions/123:approvePlan")
+        '/v1alpha/sessions/{id}:approvePlan'
+    """
+    segments = [s for s in path.split("/") if s]
+    templated: List[str] = []
+    i = 0
+    while i < len(segments):
+        templated.append(segments[i])
+        if segments[i] in _COLLECTIONS and i + 1 < len(segments):
+            size = len(segments) - i - 1 if segments[i] == "sources" else 1
+            last = segments[i + size]
+            templated.append("{id}" + (last[last.index(":") :] if ":" in last else ""))
+            i += size
+        i += 1
+    return "/" + "/".join(templated)
@@ -0,0 +1,249 @@
+"""Retry policies for the sync client.
+
+A RetryPolicy decides whether a failed attempt is retried and how long to
+wait first. The client builds an ExponentialBackoff from max_retries and
+retry_backoff_factor unless it is given a policy of its own.
+"""
+
+import logging
+import random
+import socket
+from typing import Any, Iterable, List, Optional
+
+from requests.exceptions import ConnectionError, Timeout
+
+from jules_agent_sdk.exceptions import JulesRateLimitError, is_server_error, retry_delay_hint
+
+logger = logging.getLogger(__name__)
+
+DEFAULT_MAX_ATTEMPTS = 3
+DEFAULT_MAX_BACKOFF = 10.0
+DEFAULT_MAX_RETRY_AFTER = 60.0
+
+# Categories of network-side failures, see classify_transport_error
+TRANSPORT_TIMEOUT = "timeout"
+TRANSPORT_CONNECTION_RESET = "connection_reset"
+TRANSPORT_DNS = "dns"
+TRANSPORT_CONNECTION = "connection"
+TRANSPORT_ERROR_CATEGORIES = (
+    TRANSPORT_TIMEOUT,
+    TRANSPORT_CONNECTION_RESET,
+    TRANSPORT_DNS,
+    TRANSPORT_CONNECTION,
+)
+
+
+def classify_transport_error(exception: BaseException) -> str:
+    """Classify a network-side failure.
+
+    requests wraps the underlying socket and urllib3 errors, so the exception
+    chain and arguments are searched for the root cause.
+
+    Args:
+        exception: A requests ConnectionError or Timeout
+
+    Returns:
+        One of TRANSPORT_TIMEOUT, TRANSPORT_CONNECTION_RESET, TRANSPORT_DNS or
+        TRANSPORT_CONNECTION for other connection failures
+    """
+    if isinstance(exception, Timeout):
+        return TRANSPORT_TIMEOUT
+
+    seen: List[BaseException] = []
+    pending: List[Any] = [exception]
+    while pending:
+        error = pending.pop()
+        if not isinstance(error, BaseException) or any(error is e for e in seen):
+            continue
+        seen.append(error)
+        if isinstance(error, socket.gaierror) or type(error).__name__ == "NameResolutionError":
+            return TRANSPORT_DNS
+        if isinstance(error, socket.timeout):
+            return TRANSPORT_TIMEOUT
+        pending.extend([error.__cause__, error.__context__, getattr(error, "reason", None)])
+        pending.extend(error.args)
+
+    if any(isinstance(e, (ConnectionResetError, BrokenPipeError)) for e in seen):
+        return TRANSPORT_CONNECTION_RESET
+    text = str(exception).lower()
+    if "connection reset" in text or "connection aborted" in text:
+        return TRANSPORT_CONNECTION_RESET
+    if "name or service not known" in text or "failed to resolve" in text:
+        return TRANSPORT_DNS
+    return TRANSPORT_CONNECTION
+
+
+class RetryPolicy:
+    """Decides whether and when failed requests are retried.
+
+    Network errors of the retried categories, 5xx responses and 429s are
+    retried; other errors are not. A Retry-After delay sent by the server
+    always wins over the policy's own delay. Subclasses implement backoff().
+
+    Example:
+        >>> policy = DecorrelatedJitter(max_attempts=5, no_retry_methods=["POST"])
+        >>> client = JulesClient(api_key=key, retry_policy=policy)
+    """
+
+    def __init__(
+        self,
+        max_attempts: int = DEFAULT_MAX_ATTEMPTS,
+        retry_transport_errors: Iterable[str] = TRANSPORT_ERROR_CATEGORIES,
+        max_retry_after: float = DEFAULT_MAX_RETRY_AFTER,
+        no_retry_methods: Iterable[str] = (),
+    ) -> None:
+        """Initialize the policy.
+
+        Args:
+            max_attempts: Attempts made per request, including the first
+            retry_transport_errors: Transport error categories that are retried
+            max_retry_after: Longest Retry-After delay in seconds that a 429 is
+                retried after (0 disables retrying rate limited requests)
+            no_retry_methods: HTTP methods that are never retried, e.g. ["POST"]
+        """
+        self.max_attempts = max_attempts
+        self.retry_transport_errors = frozenset(retry_transport_errors)
+        self.max_retry_after = max_retry_after
+        self.no_retry_methods = frozenset(m.upper() for m in no_retry_methods)
+
+    def should_retry(self, method: str, exception: Exception, attempt: int) -> bool:
+        """Determine if a failed attempt should be retried.
+
+        Args:
+            method: HTTP method of the request
+            exception: The exception that occurred
+            attempt: Current attempt number (1-indexed)
+
+        Returns:
+            True if should retry, False otherwise
+        """
+        if attempt >= self.max_attempts or method.upper() in self.no_retry_methods:
+            return False
+
+        # Retry on network errors of the configured categories
+        if isinstance(exception, (ConnectionError, Timeout)):
+            category = classify_transport_error(exception)
+            if category not in self.retry_transport_errors:
+                return False
+            logger.warning(
+                f"Network error ({category}) on attempt {attempt}, will retry: {exception}"
+            )
+            return True
+
+        # Retry on 429 unless the server asks for a longer wait than allowed
+        if isinstance(exception, JulesRateLimitError):
+            delay = retry_delay_hint(exception)
+            if self.max_retry_after <= 0 or (delay or 0) > self.max_retry_after:
+                return False
+            logger.warning(f"Rate limited on attempt {attempt}, will retry: {exception}")
+            return True
+
+        # Retry on 5xx errors
+        if is_server_error(exception):
+            logger.warning(f"Server error on attempt {attempt}, will retry: {exception}")
+            return True
+
+        # Don't retry on client errors (4xx)
+        return False
+
+    def next_delay(self, exception: Exception, attempt: int, previous: float) -> float:
+        """Get how long to wait before retrying after an error.
+
+        Args:
+            exception: The exception that occurred
+            attempt: Current attempt number (1-indexed)
+            previous: Delay before the current attempt, 0 for the first one
+
+        Returns:
+            The server's Retry-After delay if it sent one, else the backoff
+        """
+        delay = retry_delay_hint(exception)
+        if delay is not None:
+            return delay
+        delay = self.backoff(attempt, previous)
+        logger.debug(f"Backoff for attempt {attempt}: {delay}s")
+        return delay
+
+    def backoff(self, attempt: int, previous: float) -> float:
+        """Return the delay in seconds before the next attempt.
+
+        Args:
+            attempt: Current attempt number (1-indexed)
+            previous: Delay before the current attempt, 0 for the first one
+        """
+        raise NotImplementedError
+
+
+class ExponentialBackoff(RetryPolicy):
+    """Doubles the delay after every attempt, up to a cap. The default policy."""
+
+    def __init__(
+        self,
+        factor: float = 1.0,
+        max_backoff: float = DEFAULT_MAX_BACKOFF,
+        **kwargs: Any,
+    ) -> None:
+        """Initialize the policy.
+
+        Args:
+            factor: Delay in seconds after the first attempt
+            max_backoff: Longest delay in seconds
+            **kwargs: Passed through to RetryPolicy
+        """
+        super().__init__(**kwargs)
+        self.factor = factor
+        self.max_backoff = max_backoff
+
+    def backoff(self, attempt: int, previous: float) -> float:
+        """Return factor * 2^(attempt - 1), capped at max_backoff."""
+        return min(self.factor * (2 ** (attempt - 1)), self.max_backoff)
+
+
+class DecorrelatedJitter(RetryPolicy):
+    """Randomized backoff that spreads out clients retrying at the same time.
+
+    Each delay is drawn uniformly between base and three times the previous
+    delay, capped at cap ("decorrelated jitter").
+    """
+
+    def __init__(self, base: float = 1.0, cap: float = DEFAULT_MAX_BACKOFF, **kwargs: Any) -> None:
+        """Initialize the policy.
+
+        Args:
+            base: Shortest delay in seconds
+            cap: Longest delay in seconds
+            **kwargs: Passed through to RetryPolicy
+        """
+        super().__init__(**kwargs)
+        self.base = base
+        self.cap = cap
+
+    def backoff(self, attempt: int, previous: float) -> float:
+        """Return a random delay between base and 3 * previous, capped at cap."""
+        return min(self.cap, random.uniform(self.base, max(self.base, previous * 3)))
+
+
+class FixedDelay(RetryPolicy):
+    """Waits the same time before every retry."""
+
+    def __init__(self, delay: float = 1.0, **kwargs: Any) -> None:
+        """Initialize the policy.
+
+        Args:
+            delay: Delay in seconds before each retry
+            **kwargs: Passed through to RetryPolicy
+        """
+        super().__init__(**kwargs)
+        self.delay = delay
+
+    def backoff(self, attempt: int, previous: float) -> float:
+        """Return the fixed delay."""
+        return self.delay
+
+
+class NoRetry(FixedDelay):
+    """Never retries; every request is attempted once."""
+
+    def __init__(self) -> None:
+        """Initialize the policy."""
+        super().__init__(delay=0.0, max_attempts=1)
@@ -1,27 +1,207 @@
 """Sessions API module."""
 
+import logging
+import threading
 import time
-from typing import Optional, List, Dict, Any
-
-from jules_agent_sdk.models import Session, SessionState
+import uuid
+from contextlib import contextmanager
+from dataclasses import dataclass
+from datetime import datetime
+from typing import Optional, List, Dict, Any, Callable, Iterator, Tuple
+
+from jules_agent_sdk.models import (
+    Activity,
+    CompletionDetails,
+    Plan,
+    PlanProgress,
+    Session,
+    SessionState,
+    parse_timestamp,
+)
 from jules_agent_sdk.base import BaseClient
-from jules_agent_sdk.exceptions import JulesAPIError
+from jules_agent_sdk.context import track_requests, use_headers
+from jules_agent_sdk.decoding import decode
+from jules_agent_sdk.activities import ActivitiesAPI
+from jules_agent_sdk.artifacts import ArtifactIndex
+from jules_agent_sdk.sources import SourcesAPI
+from jules_agent_sdk.suggest import close_matches
+from jules_agent_sdk.exceptions import (
+    BranchNotFoundError,
+    JulesAPIError,
+    JulesNotFoundError,
+    JulesTimeoutError,
+    JulesValidationError,
+    PlanSupersededError,
+    PollLimitExceededError,
+    SessionStalledError,
+    WaitCancelledError,
+)
+from jules_agent_sdk.messaging import CONFLICT_STATUSES
+from jules_agent_sdk.prompt import PromptProcessor, apply_processors, check_prompt
+from jules_agent_sdk.throttle import CreateThrottle
+from jules_agent_sdk.pagination import list_params
+from jules_agent_sdk.retry import ExponentialBackoff
+from jules_agent_sdk.resources import session_path
+
+logger = logging.getLogger(__name__)
 
 # Constants for session polling
 DEFAULT_POLL_INTERVAL = 5
 DEFAULT_TIMEOUT = 600
 
+# How long approve_plan_and_wait gives an approval to take effect, and how
+# often it checks meanwhile
+DEFAULT_TRANSITION_TIMEOUT = 60
+TRANSITION_POLL_INTERVAL = 1
+
+# Backoff between retries of a conflicting plan approval, shared by the sync and
+# async clients and independent of the client's transport retry policy
+CONFLICT_BACKOFF = ExponentialBackoff()
+
+# Header carrying the key that lets the API deduplicate retried create requests
+DEFAULT_IDEMPOTENCY_KEY_HEADER = "Idempotency-Key"
+
+# Called with the outgoing create request body before it is sent. Interceptors
+# may mutate the body in place or raise to reject the request.
+CreateInterceptor = Callable[[Dict[str, Any]], None]
+
+# Called with the session and the agent's latest message when a session is
+# awaiting user feedback. Returns the reply to send; raising aborts the wait.
+FeedbackHandler = Callable[[Session, str], str]
+
+# Called with the freshly polled session and the state it was last seen in
+# (None if unknown) whenever wait_for_completion observes a state change.
+StateChangeHandler = Callable[[Session, Optional[SessionState]], None]
+
+# Called with the session and the seconds it has been seen QUEUED once that
+# exceeds queued_timeout. May return a replacement session to wait on instead.
+QueuedStallHandler = Callable[[Session, float], Optional[Session]]
+
+# Called with the session and the seconds since its updateTime last changed
+# once that exceeds max_inactivity while the session is IN_PROGRESS.
+InactivityHandler = Callable[[Session, float], None]
+
+
+@dataclass
+class WaitStats:
+    """API usage of a single wait, filled in by wait_for_completion.
+
+    Attributes:
+        polls: Number of times the session was fetched
+        requests: API requests made during the wait, including polls and any
+            made by handlers (e.g. listing activities or sending feedback)
+        elapsed: Seconds the wait took
+    """
+
+    polls: int = 0
+    requests: int = 0
+    elapsed: float = 0.0
+
+
+@contextmanager
+def _track_wait(
+    stats: Optional[WaitStats], start_time: float, clock: Callable[[], float] = time.time
+) -> Iterator[None]:
+    """Count the requests made in the block into stats, if given."""
+    if stats is None:
+        yield
+        return
+    try:
+        with track_requests(stats):
+            yield
+    finally:
+        stats.elapsed = clock() - start_time
+
+
+def _idempotency_headers(header: Optional[str], key: Optional[str]) -> Tuple[Dict[str, str], str]:
+    """Get the headers carrying a create request's idempotency key, and the key."""
+    if not header:
+        if key:
+            raise ValueError(
+                "idempotency_key was given but the client's idempotency_key_header is None"
+            )
+        return {}, ""
+    key = key or str(uuid.uuid4())
+    return {header: key}, key
+
 
 class SessionsAPI:
     """API client for managing Jules sessions."""
 
-    def __init__(self, client: BaseClient) -> None:
+    def __init__(
+        self,
+        client: BaseClient,
+        default_require_plan_approval: bool = False,
+        create_interceptors: Optional[List[CreateInterceptor]] = None,
+        max_prompt_tokens: Optional[int] = None,
+        default_page_size: Optional[int] = None,
+        strict_ids: bool = False,
+        max_creates_per_minute: Optional[int] = None,
+        prompt_processors: Optional[List[PromptProcessor]] = None,
+        pin_default_branch: bool = False,
+        idempotency_key_header: Optional[str] = DEFAULT_IDEMPOTENCY_KEY_HEADER,
+    ) -> None:
         """Initialize the Sessions API.
 
         Args:
             client: Base HTTP client instance
+            default_require_plan_approval: Value used when create() is called
+                without an explicit require_plan_approval
+            create_interceptors: Interceptors applied, in order, to every create request
+            max_prompt_tokens: Optional estimated token limit checked locally before
+                prompts and messages are sent
+            default_page_size: Page size used when a call does not specify one
+            strict_ids: Reject URLs and other ambiguous session IDs instead of
+                normalizing them
+            max_creates_per_minute: Optional limit on sessions created per minute;
+                calls over the limit wait locally for a free slot
+            prompt_processors: Processors applied, in order, to every prompt and
+                message before it is sent
+            pin_default_branch: Value used when create() is called without an
+                explicit pin_default_branch
+            idempotency_key_header: Header the create idempotency key is sent
+                in; None sends no key
         """
         self.client = client
+        self.default_require_plan_approval = default_require_plan_approval
+        self.create_interceptors: List[CreateInterceptor] = list(create_interceptors or [])
+        self.max_prompt_tokens = max_prompt_tokens
+        self.prompt_processors: List[PromptProcessor] = list(prompt_processors or [])
+        self.default_page_size = default_page_size
+        self.strict_ids = strict_ids
+        self.pin_default_branch = pin_default_branch
+        self.idempotency_key_header = idempotency_key_header
+        self._activities = ActivitiesAPI(client, default_page_size, strict_ids)
+        self._sources = SourcesAPI(client, default_page_size, strict_ids)
+        self.create_throttle: Optional[CreateThrottle] = None
+        if max_creates_per_minute is not None:
+            self.create_throttle = CreateThrottle(max_creates_per_minute)
+
+    def add_create_interceptor(self, interceptor: CreateInterceptor) -> None:
+        """Append an interceptor to the create request chain.
+
+        Args:
+            interceptor: Callable receiving the request body; may mutate it or raise
+
+        Example:
+            >>> def require_title(request):
+            ...     if not request.get("title"):
+            ...         raise ValueError("Sessions must have a title")
+            >>> client.sessions.add_create_interceptor(require_title)
+        """
+        self.create_interceptors.append(interceptor)
+
+    def add_prompt_processor(self, processor: PromptProcessor) -> None:
+        """Append a processor to the chain applied to outgoing prompts and messages.
+
+        Args:
+            processor: Callable receiving the prompt text and returning the text to send
+
+        Example:
+            >>> from jules_agent_sdk.prompt import scrub_emails
+            >>> client.sessions.add_prompt_processor(scrub_emails)
+        """
+        self.prompt_processors.append(processor)
 
     def create(
         self,
@@ -29,20 +209,45 @@ class SessionsAPI:
         source: str,
         starting_branch: Optional[str] = None,
         title: Optional[str] = None,
-        require_plan_approval: bool = False,
+        require_plan_approval: Optional[bool] = None,
+        validate_branch: bool = False,
+        pin_default_branch: Optional[bool] = None,
+        idempotency_key: Optional[str] = None,
     ) -> Session:
         """Create a new session.
 
         Args:
             prompt: The prompt to start the session with
-            source: The source to use (e.g., "sources/abc123")
+            source: The source to use (e.g., "sources/abc123"), as a resource name,
+                source ID or GitHub "owner/repo" shorthand
             starting_branch: Optional starting branch for GitHub repos
             title: Optional session title
-            require_plan_approval: If True, plans require explicit approval
+            require_plan_approval: If True, plans require explicit approval. Defaults
+                to the client's default_require_plan_approval when not given
+            validate_branch: If True, check starting_branch against the source's
+                branches before creating, so a typo fails immediately rather than
+                minutes into the session
+            pin_default_branch: If True and starting_branch is not given, look up
+                the source's current default branch and send it as the starting
+                branch, so the session records exactly which base it started from
+                even if the repository's default changes later. Defaults to the
+                client's pin_default_branch when not given
+            idempotency_key: Key sent with the request, and with every retry of
+                it, so a create retried after a network error does not start a
+                second session. Defaults to a new random UUID; pass your own
+                to deduplicate across processes. Set on the returned session.
+                Must not be given when the client's idempotency_key_header is None
 
         Returns:
             Created Session object
 
+        Raises:
+            PromptTooLargeError: If the prompt exceeds max_prompt_tokens
+            BranchNotFoundError: If validate_branch is set and the branch is missing
+            Exception: Whatever a create interceptor raises to reject the request
+            ValueError: If idempotency_key is given but the client sends no
+                idempotency key header
+
         Example:
             >>> client = JulesClient(api_key="your-api-key")
             >>> session = client.sessions.create(
@@ -52,22 +257,75 @@ class SessionsAPI:
             ... )
             >>> print(session.id)
         """
+        headers, idempotency_key = _idempotency_headers(
+            self.idempotency_key_header, idempotency_key
+        )
+        source = self._sources.resolve_name(source)
         data: Dict[str, Any] = {
-            "prompt": prompt,
+            "prompt": apply_processors(prompt, self.prompt_processors),
             "sourceContext": {"source": source},
         }
 
         if starting_branch:
             data["sourceContext"]["githubRepoContext"] = {"startingBranch": starting_branch}
 
+        if pin_default_branch is None:
+            pin_default_branch = self.pin_default_branch
+
+        if pin_default_branch and not starting_branch:
+            self._pin_default_branch(data["sourceContext"])
+
         if title:
             data["title"] = title
 
+        if require_plan_approval is None:
+            require_plan_approval = self.default_require_plan_approval
+
         if require_plan_approval:
             data["requirePlanApproval"] = require_plan_approval
 
-        response = self.client.post("sessions", json=data)
-        return Session.from_dict(response)
+        for interceptor in self.create_interceptors:
+            interceptor(data)
+
+        if self.max_prompt_tokens is not None:
+            check_prompt(data["prompt"], self.max_prompt_tokens)
+
+        if validate_branch:
+            self._validate_branch(data["sourceContext"])
+
+        if self.create_throttle is not None:
+            self.create_throttle.wait()
+
+        with use_headers(headers):
+            response = self.client.post("sessions", json=data)
+        session = decode(Session, response, self.client.decode_options)
+        session.idempotency_key = idempotency_key
+        return session
+
+    def _pin_default_branch(self, source_context: Dict[str, Any]) -> None:
+        """Set a create request's starting branch to its source's default branch."""
+        branch = self._sources.get(source_context["source"]).default_branch_name
+        if not branch:
+            logger.debug(f"No default branch for {source_context['source']}; not pinning")
+            return
+        logger.debug(f"Pinning {source_context['source']} to its default branch {branch}")
+        source_context["githubRepoContext"] = {"startingBranch": branch}
+
+    def _validate_branch(self, source_context: Dict[str, Any]) -> None:
+        """Check a create request's starting branch exists in its source."""
+        branch = source_context.get("githubRepoContext", {}).get("startingBranch")
+        if not branch:
+            return
+
+        github_repo = self._sources.get(source_context["source"]).github_repo
+        if github_repo is None or not github_repo.branches:
+            logger.debug(f"No branch list for {source_context['source']}; skipping validation")
+            return
+
+        names = [b.display_name for b in github_repo.branches]
+        if branch not in names:
+            suggestions = close_matches(branch, names)
+            raise BranchNotFoundError(branch, source_context["source"], suggestions)
 
     def get(self, session_id: str) -> Session:
         """Get a single session by ID.
@@ -82,11 +340,10 @@ class SessionsAPI:
             >>> session = client.sessions.get("abc123")
             >>> print(session.state)
         """
-        if not session_id.startswith("sessions/"):
-            session_id = f"sessions/{session_id}"
+        session_id = session_path(session_id, self.strict_ids)
 
         response = self.client.get(session_id)
-        return Session.from_dict(response)
+        return decode(Session, response, self.client.decode_options)
 
     def list(
         self, page_size: Optional[int] = None, page_token: Optional[str] = None
@@ -94,7 +351,8 @@ class SessionsAPI:
         """List all sessions.
 
         Args:
-            page_size: Maximum number of sessions to return
+            page_size: Maximum number of sessions to return (clamped to the API
+                maximum; defaults to the client's default_page_size)
             page_token: Token for pagination
 
         Returns:
@@ -105,36 +363,120 @@ class SessionsAPI:
             >>> for session in result['sessions']:
             ...     print(session.id, session.state)
         """
-        params: Dict[str, Any] = {}
-        if page_size is not None:
-            params["pageSize"] = page_size
-        if page_token:
-            params["pageToken"] = page_token
+        params = list_params(page_size, page_token, self.default_page_size)
 
         response = self.client.get("sessions", params=params)
 
         sessions = []
         if response.get("sessions"):
-            sessions = [Session.from_dict(s) for s in response["sessions"]]
+            options = self.client.decode_options
+            sessions = [decode(Session, s, options) for s in response["sessions"]]
 
         return {
             "sessions": sessions,
             "nextPageToken": response.get("nextPageToken"),
         }
 
-    def approve_plan(self, session_id: str) -> None:
+    def approve_plan(
+        self, session_id: str, plan_id: Optional[str] = None, max_attempts: int = 3
+    ) -> None:
         """Approve a plan in a session.
 
+        Approving while the agent regenerates its plan can be rejected with a
+        conflict. If plan_id is given, a rejected approval is checked against
+        the session: it is retried, after an exponential backoff, while that
+        plan is still awaiting approval, and PlanSupersededError is raised once
+        a different plan has replaced it.
+
         Args:
             session_id: The session ID or full name
+            plan_id: ID of the plan being approved, e.g. from Activity.plan
+            max_attempts: Approval attempts when plan_id is given (default: 3)
+
+        Raises:
+            PlanSupersededError: If a different plan now awaits approval
 
         Example:
             >>> client.sessions.approve_plan("abc123")
+            >>> client.sessions.approve_plan("abc123", plan_id=plan.id)
+        """
+        session_id = session_path(session_id, self.strict_ids)
+
+        delay = 0.0
+        for attempt in range(1, max_attempts + 1):
+            try:
+                self.client.post(f"{session_id}:approvePlan")
+                return
+            except JulesAPIError as e:
+                if plan_id is None or e.status_code not in CONFLICT_STATUSES:
+                    raise
+                if self.get(session_id).state != SessionState.AWAITING_PLAN_APPROVAL:
+                    raise
+                plan = self._latest_plan(session_id)
+                if plan is None or plan.id != plan_id:
+                    raise PlanSupersededError(session_id, plan_id, plan) from e
+                if attempt == max_attempts:
+                    raise
+                delay = CONFLICT_BACKOFF.next_delay(e, attempt, delay)
+                logger.info(f"Approval of plan {plan_id} conflicted; retrying in {delay}s")
+                time.sleep(delay)
+
+    def _latest_plan(self, session_id: str) -> Optional[Plan]:
+        """Find the most recently generated plan of a session."""
+        plans = [a.plan for a in self._activities.list_all(session_id) if a.plan is not None]
+        return plans[-1] if plans else None
+
+    def approve_plan_and_wait(
+        self,
+        session_id: str,
+        plan_id: Optional[str] = None,
+        transition_timeout: float = DEFAULT_TRANSITION_TIMEOUT,
+        **wait_kwargs: Any,
+    ) -> Session:
+        """Approve a plan and wait for the session to complete.
+
+        The session can keep reporting AWAITING_PLAN_APPROVAL for a moment after
+        the approval is accepted. This polls until the approval has taken effect
+        before waiting for completion, so that stale state is never mistaken for
+        a plan still awaiting approval. With plan_id, a newer plan awaiting
+        approval also counts as the approval having taken effect.
+
+        Args:
+            session_id: The session ID or full name
+            plan_id: ID of the plan being approved, see approve_plan
+            transition_timeout: Seconds the approval may take to take effect
+                (default: 60)
+            **wait_kwargs: Options accepted by wait_for_completion
+
+        Returns:
+            Final Session object
+
+        Raises:
+            PlanSupersededError: If a different plan awaited approval
+            SessionStalledError: If the session is still awaiting approval of the
+                same plan after transition_timeout
+            JulesTimeoutError: If the wait for completion times out
+
+        Example:
+            >>> final = client.sessions.approve_plan_and_wait("abc123", plan_id=plan.id)
         """
-        if not session_id.startswith("sessions/"):
-            session_id = f"sessions/{session_id}"
+        session_id = session_path(session_id, self.strict_ids)
+        self.approve_plan(session_id, plan_id=plan_id)
 
-        self.client.post(f"{session_id}:approvePlan")
+        start_time = time.time()
+        while self.get(session_id).state == SessionState.AWAITING_PLAN_APPROVAL:
+            if plan_id is not None:
+                plan = self._latest_plan(session_id)
+                if plan is not None and plan.id != plan_id:
+                    logger.info(f"Plan {plan_id} was approved and replaced by {plan.id}")
+                    break
+            waited = time.time() - start_time
+            if waited > transition_timeout:
+                raise SessionStalledError(session_id, "AWAITING_PLAN_APPROVAL", waited)
+            time.sleep(TRANSITION_POLL_INTERVAL)
+
+        wait_kwargs.setdefault("last_known_state", SessionState.AWAITING_PLAN_APPROVAL)
+        return self.wait_for_completion(session_id, **wait_kwargs)
 
     def send_message(self, session_id: str, prompt: str) -> None:
         """Send a message from the user to a session.
@@ -143,32 +485,226 @@ class SessionsAPI:
             session_id: The session ID or full name
             prompt: The message to send
 
+        Raises:
+            PromptTooLargeError: If the message exceeds max_prompt_tokens
+
         Example:
             >>> client.sessions.send_message("abc123", "Please also add unit tests")
         """
-        if not session_id.startswith("sessions/"):
-            session_id = f"sessions/{session_id}"
+        session_id = session_path(session_id, self.strict_ids)
+
+        prompt = apply_processors(prompt, self.prompt_processors)
+        if self.max_prompt_tokens is not None:
+            check_prompt(prompt, self.max_prompt_tokens)
 
         self.client.post(f"{session_id}:sendMessage", json={"prompt": prompt})
 
+    def _last_agent_activity(self, session_id: str) -> Optional[Activity]:
+        """Find the most recent activity carrying an agent message.
+
+        Args:
+            session_id: The session ID or full name
+
+        Returns:
+            The latest agent message activity, or None if the agent has not messaged
+        """
+        messages = [
+            a
+            for a in self._activities.list_all(session_id)
+            if a.agent_messaged is not None
+        ]
+        # Activities are listed in chronological order, so the last one is the newest
+        return messages[-1] if messages else None
+
+    def last_agent_message(self, session_id: str) -> Tuple[str, Optional[datetime]]:
+        """Get the most recent message the agent sent in a session.
+
+        Args:
+            session_id: The session ID or full name
+
+        Returns:
+            Tuple of (message text, create time as a timezone-aware datetime). The
+            text is empty and the time is None if the agent has not sent a message yet.
+
+        Example:
+            >>> message, sent_at = client.sessions.last_agent_message("abc123")
+            >>> if message:
+            ...     print(f"Jules asked at {sent_at}: {message}")
+        """
+        activity = self._last_agent_activity(session_id)
+        if activity is None:
+            return "", None
+        return activity.agent_message, parse_timestamp(activity.create_time)
+
+    def retry_failed(self, session_id: str, include_failure_reason: bool = True) -> Session:
+        """Start a new session repeating the task of a failed one.
+
+        The new session reuses the original prompt, source, starting branch, title
+        and plan approval setting.
+
+        Args:
+            session_id: The failed session's ID or full name
+            include_failure_reason: Append the original session name and failure
+                reason to the prompt as additional context (default: True)
+
+        Returns:
+            The newly created Session
+
+        Raises:
+            JulesValidationError: If the session is not in the FAILED state
+
+        Example:
+            >>> retry = client.sessions.retry_failed("abc123")
+            >>> client.sessions.wait_for_completion(retry.id)
+        """
+        original = self.get(session_id)
+        if original.state != SessionState.FAILED:
+            raise JulesValidationError(
+                f"Only failed sessions can be retried; {original.name or session_id} "
+                f"is {original.state.value}"
+            )
+
+        prompt = original.prompt
+        if include_failure_reason:
+            reason = ""
+            for activity in self._activities.list_all(session_id):
+                if activity.session_failed:
+                    reason = activity.failure_reason
+            prompt += f"\n\nA previous attempt ({original.name or session_id}) failed"
+            prompt += f": {reason}" if reason else "."
+
+        repo_context = original.source_context.github_repo_context
+        return self.create(
+            prompt=prompt,
+            source=original.source_context.source,
+            starting_branch=repo_context.starting_branch if repo_context else None,
+            title=original.title or None,
+            require_plan_approval=original.require_plan_approval,
+        )
+
+    def get_completion_details(self, session_id: str) -> CompletionDetails:
+        """Get the outcome of a completed session.
+
+        Args:
+            session_id: The session ID or full name
+
+        Returns:
+            CompletionDetails with the summary, pull requests, final change sets and
+            any additional fields of the sessionCompleted activity
+
+        Example:
+            >>> details = client.sessions.get_completion_details("abc123")
+            >>> for pr in details.pull_requests:
+            ...     print(pr.url)
+        """
+        session = self.get(session_id)
+        activities = self._activities.list_all(session_id)
+        return CompletionDetails.from_session(session, activities)
+
+    def get_progress(self, session_id: str) -> Optional[PlanProgress]:
+        """Get how far a session has got through its plan.
+
+        Args:
+            session_id: The session ID or full name
+
+        Returns:
+            PlanProgress, or None if the session has no plan yet
+
+        Example:
+            >>> progress = client.sessions.get_progress("abc123")
+            >>> if progress:
+            ...     print(f"{progress} ({progress.fraction:.0%})")
+        """
+        return PlanProgress.from_activities(self._activities.list_all(session_id))
+
+    def artifacts(self, session_id: str) -> ArtifactIndex:
+        """Get an index of all artifacts produced by a session.
+
+        The session's activities are listed on first access to the index.
+
+        Args:
+            session_id: The session ID or full name
+
+        Returns:
+            ArtifactIndex grouping the artifacts by type
+
+        Example:
+            >>> artifacts = client.sessions.artifacts("abc123")
+            >>> print(artifacts.counts())
+            >>> for output in artifacts.bash_outputs:
+            ...     print(output.command, output.exit_code)
+        """
+        return ArtifactIndex(lambda: self._activities.list_all(session_id))
+
     def wait_for_completion(
         self,
         session_id: str,
         poll_interval: int = DEFAULT_POLL_INTERVAL,
         timeout: Optional[int] = DEFAULT_TIMEOUT,
+        on_feedback_requested: Optional[FeedbackHandler] = None,
+        last_known_state: Optional[SessionState] = None,
+        on_state_change: Optional[StateChangeHandler] = None,
+        queued_timeout: Optional[float] = None,
+        on_queued_stall: Optional[QueuedStallHandler] = None,
+        max_inactivity: Optional[float] = None,
+        on_inactive: Optional[InactivityHandler] = None,
+        cancel_event: Optional[threading.Event] = None,
+        not_found_grace: Optional[float] = None,
+        stats: Optional[WaitStats] = None,
+        max_polls: Optional[int] = None,
     ) -> Session:
         """Poll a session until it completes or fails.
 
+        To resume a wait after a restart, persist the state passed to
+        on_state_change and hand it back as last_known_state. If the session
+        moved on while nobody was watching, the first poll reports that change
+        as a single transition from last_known_state.
+
         Args:
             session_id: The session ID or full name
             poll_interval: Seconds between polling requests (default: 5)
             timeout: Optional timeout in seconds (default: 600)
+            on_feedback_requested: Optional handler invoked once per agent question
+                while the session is AWAITING_USER_FEEDBACK. Its non-empty return
+                value is sent as the reply; raising aborts the wait.
+            last_known_state: State the caller last saw the session in, if any
+            on_state_change: Optional callback invoked with (session, previous_state)
+                whenever a poll observes a different state, including the first
+                poll when the state differs from last_known_state
+            queued_timeout: Optional seconds the session may stay QUEUED, measured
+                from the first poll that saw it queued
+            on_queued_stall: Optional handler invoked once with (session, queued_for)
+                when queued_timeout is exceeded. If it returns a session (e.g. one
+                created with retry_failed), the wait continues on that session;
+                returning None keeps waiting. Without a handler the wait raises.
+                The API cannot cancel sessions, so a replaced session is left as is.
+            max_inactivity: Optional seconds an IN_PROGRESS session may go without
+                its updateTime changing, catching hung sessions well before timeout
+            on_inactive: Optional callback invoked with (session, inactive_for) each
+                time max_inactivity elapses without progress. Without it the wait
+                raises.
+            cancel_event: Optional event that stops the wait as soon as it is set,
+                e.g. from a signal handler when a CI pipeline is aborted. The API
+                has no cancel endpoint, so the session itself keeps running. Use a
+                CancelEvent to attach a reason to the WaitCancelledError.
+            not_found_grace: Optional seconds from the start of the wait during which
+                404 responses are retried, for waits started right after create()
+                while the new session may not be visible yet
+            stats: Optional WaitStats that is filled in with the polls and API
+                requests the wait consumed, even if it raises
+            max_polls: Optional cap on the number of polls, for callers with a
+                request budget
 
         Returns:
             Final Session object
 
         Raises:
-            TimeoutError: If timeout is reached
+            JulesTimeoutError: If timeout is reached (a subclass of TimeoutError)
+            PollLimitExceededError: If max_polls is reached
+            SessionStalledError: If queued_timeout or max_inactivity is exceeded
+                without the matching handler
+            WaitCancelledError: If cancel_event is set
+            JulesNotFoundError: If the session does not exist, after not_found_grace
             JulesAPIError: If session fails
 
         Example:
@@ -181,16 +717,101 @@ class SessionsAPI:
             SessionState.COMPLETED,
             SessionState.FAILED,
         }
-
-        while True:
-            session = self.get(session_id)
-
-            if session.state in terminal_states:
-                if session.state == SessionState.FAILED:
-                    raise JulesAPIError(f"Session failed: {session_id}")
-                return session
-
-            if timeout and (time.time() - start_time) > timeout:
-                raise TimeoutError(f"Session polling timed out after {timeout} seconds")
-
-            time.sleep(poll_interval)
+        answered: Optional[str] = None
+        state = last_known_state
+        queued_since: Optional[float] = None
+        stall_reported = False
+        update_time: Optional[str] = None
+        active_at = start_time
+        polls = 0
+
+        with _track_wait(stats, start_time):
+            while True:
+                if cancel_event is not None and cancel_event.is_set():
+                    raise WaitCancelledError(
+                        session_path(session_id, self.strict_ids),
+                        getattr(cancel_event, "reason", None),
+                    )
+                if max_polls is not None and polls >= max_polls:
+                    raise PollLimitExceededError(
+                        session_path(session_id, self.strict_ids), max_polls
+                    )
+                polls += 1
+                if stats is not None:
+                    stats.polls = polls
+                try:
+                    session = self.get(session_id)
+                except JulesNotFoundError:
+                    if not not_found_grace or time.time() - start_time > not_found_grace:
+                        raise
+                    logger.debug(f"{session_id} not found yet; retrying within grace window")
+                    if cancel_event is not None:
+                        cancel_event.wait(poll_interval)
+                    else:
+                        time.sleep(poll_interval)
+                    continue
+
+                changed = session.update_time != update_time or session.state != state
+                if max_inactivity and changed:
+                    update_time = session.update_time
+                    active_at = time.time()
+
+                if session.state != state:
+                    self.client._notify(on_state_change, session, state)
+                    state = session.state
+
+                if session.state in terminal_states:
+                    if session.state == SessionState.FAILED:
+                        raise JulesAPIError(f"Session failed: {session_id}")
+                    return session
+
+                if session.state != SessionState.QUEUED:
+                    queued_since = None
+                elif queued_since is None:
+                    queued_since = time.time()
+                elif queued_timeout and not stall_reported:
+                    queued_for = time.time() - queued_since
+                    if queued_for > queued_timeout:
+                        if on_queued_stall is None:
+                            raise SessionStalledError(
+                                session.name or session_id, "QUEUED", queued_for
+                            )
+                        stall_reported = True
+                        replacement = on_queued_stall(session, queued_for)
+                        if replacement is not None:
+                            logger.info(f"Replacing stalled {session_id} with {replacement.name}")
+                            session_id = replacement.name or replacement.id
+                            state, queued_since, stall_reported = None, None, False
+                            continue
+
+                if max_inactivity and session.state == SessionState.IN_PROGRESS:
+                    inactive_for = time.time() - active_at
+                    if inactive_for > max_inactivity:
+                        if on_inactive is None:
+                            raise SessionStalledError(
+                                session.name or session_id, "IN_PROGRESS", inactive_for
+                            )
+                        self.client._notify(on_inactive, session, inactive_for)
+                        active_at = time.time()
+
+                if (
+                    on_feedback_requested is not None
+                    and session.state == SessionState.AWAITING_USER_FEEDBACK
+                ):
+                    question = self._last_agent_activity(session_id)
+                    if question is not None and question.name != answered:
+                        message = question.agent_message
+                        reply = on_feedback_requested(session, message)
+                        answered = question.name
+                        if reply:
+                            self.send_message(session_id, reply)
+                            continue
+
+                elapsed = time.time() - start_time
+                if timeout and elapsed > timeout:
+                    raise JulesTimeoutError(session.name or session_id, timeout, elapsed)
+
+                if cancel_event is not None:
+                    cancel_event.wait(poll_interval)
+                else:
+                    time.sleep(poll_interval)
@@ -0,0 +1,55 @@
+"""Memoized mapping from GitHub "owner/repo" shorthands to source names."""
+
+import threading
+from typing import Dict, Iterable, Optional
+
+from jules_agent_sdk.models import Source
+
+
+def is_repo_shorthand(value: str) -> bool:
+    """Whether a source identifier is a GitHub "owner/repo" shorthand.
+
+    Resource names start with "sources/" and source IDs have at least two
+    slashes ("github/owner/repo"), so exactly one slash means "owner/repo".
+    """
+    value = value.strip()
+    return not value.startswith("sources/") and value.count("/") == 1
+
+
+class SourceNameCache:
+    """Thread-safe map from "owner/repo" to source resource names.
+
+    Resolving a shorthand requires listing every source, so each client keeps
+    the names it has seen. Keys match exactly, like SourcesAPI.resolve does.
+    Entries are dropped when the cached name stops resolving.
+    """
+
+    def __init__(self) -> None:
+        """Initialize an empty cache."""
+        self._names: Dict[str, str] = {}
+        self._lock = threading.Lock()
+
+    def get(self, repo: str) -> Optional[str]:
+        """Look up the resource name for an "owner/repo" shorthand."""
+        with self._lock:
+            return self._names.get(repo.strip())
+
+    def update(self, sources: Iterable[Source]) -> None:
+        """Remember the names of the given GitHub sources."""
+        entries = {
+            f"{s.github_repo.owner}/{s.github_repo.repo}": s.name
+            for s in sources
+            if s.github_repo and s.name
+        }
+        with self._lock:
+            self._names.update(entries)
+
+    def forget(self, repo: str) -> None:
+        """Drop the entry for an "owner/repo" shorthand, if any."""
+        with self._lock:
+            self._names.pop(repo.strip(), None)
+
+    def clear(self) -> None:
+        """Drop all entries."""
+        with self._lock:
+            self._names.clear()
@@ -1,26 +1,40 @@
 """Sources API module."""
 
-from typing import Optional, List, Dict, Any
+from typing import Optional, List, Dict, Any, Callable, Iterator
 from jules_agent_sdk.models import Source
 from jules_agent_sdk.base import BaseClient
+from jules_agent_sdk.filters import SourceFilterLike, split_filter
+from jules_agent_sdk.decoding import decode
+from jules_agent_sdk.exceptions import JulesNotFoundError, SourceNotFoundError
+from jules_agent_sdk.pagination import DEFAULT_MAX_PAGES, PageTracker, list_params
+from jules_agent_sdk.resources import source_path
+from jules_agent_sdk.sourcemap import is_repo_shorthand
+from jules_agent_sdk.suggest import close_matches
 
 
 class SourcesAPI:
     """API client for managing Jules sources."""
 
-    def __init__(self, client: BaseClient) -> None:
+    def __init__(
+        self, client: BaseClient, default_page_size: Optional[int] = None, strict_ids: bool = False
+    ) -> None:
         """Initialize the Sources API.
 
         Args:
             client: Base HTTP client instance
+            default_page_size: Page size used when a call does not specify one
+            strict_ids: Reject ambiguous IDs instead of normalizing them
         """
         self.client = client
+        self.default_page_size = default_page_size
+        self.strict_ids = strict_ids
 
     def get(self, source_id: str) -> Source:
         """Get a single source by ID.
 
         Args:
-            source_id: The source ID or full name (e.g., "sources/abc123" or "abc123")
+            source_id: The source ID or full name (e.g., "sources/abc123" or "abc123"),
+                or a GitHub "owner/repo" shorthand
 
         Returns:
             Source object
@@ -30,23 +44,25 @@ class SourcesAPI:
             >>> if source.github_repo:
             ...     print(f"Repo: {source.github_repo.owner}/{source.github_repo.repo}")
         """
-        if not source_id.startswith("sources/"):
-            source_id = f"sources/{source_id}"
+        if is_repo_shorthand(source_id):
+            return self.resolve(source_id)
+        source_id = source_path(source_id, self.strict_ids)
 
         response = self.client.get(source_id)
-        return Source.from_dict(response)
+        return decode(Source, response, self.client.decode_options)
 
     def list(
         self,
-        filter_str: Optional[str] = None,
+        filter_str: Optional[SourceFilterLike] = None,
         page_size: Optional[int] = None,
         page_token: Optional[str] = None,
     ) -> Dict[str, Any]:
         """List sources.
 
         Args:
-            filter_str: Optional filter string
-            page_size: Maximum number of sources to return
+            filter_str: Optional filter string or SourceFilter
+            page_size: Maximum number of sources to return (clamped to the API
+                maximum; defaults to the client's default_page_size)
             page_token: Token for pagination
 
         Returns:
@@ -60,48 +76,186 @@ class SourcesAPI:
             >>> if result['nextPageToken']:
             ...     next_page = client.sources.list(page_token=result['nextPageToken'])
         """
-        params: Dict[str, Any] = {}
-        if filter_str:
-            params["filter"] = filter_str
-        if page_size is not None:
-            params["pageSize"] = page_size
-        if page_token:
-            params["pageToken"] = page_token
+        expression, source_filter = split_filter(filter_str)
+        params = list_params(page_size, page_token, self.default_page_size, expression)
 
         response = self.client.get("sources", params=params)
 
         sources = []
         if response.get("sources"):
-            sources = [Source.from_dict(s) for s in response["sources"]]
+            options = self.client.decode_options
+            sources = [decode(Source, s, options) for s in response["sources"]]
+            if source_filter is not None:
+                sources = [s for s in sources if source_filter.matches(s)]
 
         return {
             "sources": sources,
             "nextPageToken": response.get("nextPageToken"),
         }
 
-    def list_all(self, filter_str: Optional[str] = None) -> List[Source]:
+    def list_all(
+        self,
+        filter_str: Optional[SourceFilterLike] = None,
+        page_size: Optional[int] = None,
+        max_pages: Optional[int] = DEFAULT_MAX_PAGES,
+    ) -> List[Source]:
         """List all sources (handles pagination automatically).
 
         Args:
-            filter_str: Optional filter string
+            filter_str: Optional filter string or SourceFilter
+            page_size: Sources fetched per request
+            max_pages: Safety limit on pages fetched (default: 1000, None for no limit)
 
         Returns:
             List of all Source objects
 
+        Raises:
+            PaginationLoopError: If a page token repeats or max_pages is exceeded
+
         Example:
             >>> all_sources = client.sources.list_all()
             >>> github_sources = [s for s in all_sources if s.github_repo]
             >>> print(f"GitHub sources: {len(github_sources)}")
         """
-        all_sources: List[Source] = []
+        return list(self.iter_all(filter_str=filter_str, page_size=page_size, max_pages=max_pages))
+
+    def iter_all(
+        self,
+        filter_str: Optional[SourceFilterLike] = None,
+        page_size: Optional[int] = None,
+        max_pages: Optional[int] = DEFAULT_MAX_PAGES,
+    ) -> Iterator[Source]:
+        """Iterate over all sources, fetching pages only as they are consumed.
+
+        Args:
+            filter_str: Optional filter string or SourceFilter
+            page_size: Sources fetched per request
+            max_pages: Safety limit on pages fetched (default: 1000, None for no limit)
+
+        Yields:
+            Source objects, in API order
+
+        Raises:
+            PaginationLoopError: If a page token repeats or max_pages is exceeded
+        """
         page_token: Optional[str] = None
+        tracker = PageTracker("sources", max_pages)
 
         while True:
-            result = self.list(filter_str=filter_str, page_token=page_token)
-            all_sources.extend(result["sources"])
+            result = self.list(filter_str=filter_str, page_size=page_size, page_token=page_token)
+            yield from result["sources"]
 
-            page_token = result.get("nextPageToken")
+            page_token = tracker.advance(result.get("nextPageToken"))
             if not page_token:
                 break
 
-        return all_sources
+    def iter_filter(
+        self,
+        predicate: Callable[[Source], bool],
+        filter_str: Optional[SourceFilterLike] = None,
+        page_size: Optional[int] = None,
+        max_pages: Optional[int] = DEFAULT_MAX_PAGES,
+    ) -> Iterator[Source]:
+        """Iterate over the sources matching a client-side predicate.
+
+        Pages are fetched lazily, so stopping early, e.g. after the first
+        match, saves the remaining requests.
+
+        Args:
+            predicate: Called with each source; sources it returns True for are yielded
+            filter_str: Optional filter string or SourceFilter applied first
+            page_size: Sources fetched per request
+            max_pages: Safety limit on pages fetched (default: 1000, None for no limit)
+
+        Yields:
+            Matching Source objects, in API order
+
+        Raises:
+            PaginationLoopError: If a page token repeats or max_pages is exceeded
+
+        Example:
+            >>> def is_octo(source):
+            ...     return bool(source.github_repo) and source.github_repo.owner == "octo"
+            >>> for source in client.sources.iter_filter(is_octo):
+            ...     print(source.name)
+        """
+        sources = self.iter_all(filter_str=filter_str, page_size=page_size, max_pages=max_pages)
+        for source in sources:
+            if predicate(source):
+                yield source
+
+    def resolve(self, name_or_repo: str) -> Source:
+        """Find a source by resource name, source ID or GitHub "owner/repo".
+
+        Args:
+            name_or_repo: "sources/github/owner/repo", "github/owner/repo" or
+                "owner/repo"
+
+        Returns:
+            The matching Source
+
+        Raises:
+            SourceNotFoundError: If no accessible repository matches; its
+                suggestions list the closest names the key can access
+            JulesNotFoundError: If a source name or ID does not exist
+
+        Example:
+            >>> source = client.sources.resolve("octo/app")
+            >>> print(source.name)
+        """
+        value = name_or_repo.strip()
+        if not is_repo_shorthand(value):
+            return self.get(value)
+
+        name = self.client.source_names.get(value)
+        if name is not None:
+            try:
+                return self.get(name)
+            except JulesNotFoundError:
+                self.client.source_names.forget(value)
+        return self._find_repo(value)
+
+    def resolve_name(self, identifier: str) -> str:
+        """Normalize any source identifier to its full resource name.
+
+        Resource names and source IDs are normalized locally. GitHub "owner/repo"
+        shorthands are looked up once and then memoized on the client.
+
+        Args:
+            identifier: "sources/github/owner/repo", "github/owner/repo" or
+                "owner/repo"
+
+        Returns:
+            The source resource name, e.g. "sources/github/owner/repo"
+
+        Raises:
+            SourceNotFoundError: If no accessible repository matches a shorthand
+
+        Example:
+            >>> client.sources.resolve_name("octo/app")
+            'sources/github/octo/app'
+        """
+        value = identifier.strip()
+        if not is_repo_shorthand(value):
+            return source_path(value, self.strict_ids)
+        return self.client.source_names.get(value) or self._find_repo(value).name
+
+    def clear_cache(self) -> None:
+        """Forget memoized "owner/repo" lookups, e.g. after repositories are renamed."""
+        self.client.source_names.clear()
+
+    def _find_repo(self, repo: str) -> Source:
+        """Scan all sources for a GitHub repository, memoizing every name seen."""
+        owner, name = repo.split("/")
+        sources = self.list_all()
+        self.client.source_names.update(sources)
+
+        candidates: List[str] = []
+        for source in sources:
+            github_repo = source.github_repo
+            if github_repo:
+                if github_repo.owner == owner and github_repo.repo == name:
+                    return source
+                candidates.append(f"{github_repo.owner}/{github_repo.repo}")
+
+        raise SourceNotFoundError(repo, close_matches(repo, candidates))
@@ -0,0 +1,30 @@
+"""Close-match suggestions for names the caller probably mistyped."""
+
+import difflib
+from typing import Dict, Iterable, List
+
+
+def close_matches(value: str, candidates: Iterable[str], limit: int = 3) -> List[str]:
+    """Find the candidates closest to a mistyped value.
+
+    Matching ignores case, so a candidate differing only in casing is always
+    ranked first.
+
+    Args:
+        value: The name that was not found
+        candidates: Names that do exist
+        limit: Maximum number of suggestions
+
+    Returns:
+        Up to limit candidates, best match first
+
+    Example:
+        >>> close_matches("mian", ["main", "develop", "maint"])
+        ['main', 'maint']
+    """
+    by_lower: Dict[str, str] = {}
+    for candidate in candidates:
+        by_lower.setdefault(candidate.lower(), candidate)
+
+    matches = difflib.get_close_matches(value.lower(), list(by_lower), n=limit)
+    return [by_lower[m] for m in matches]
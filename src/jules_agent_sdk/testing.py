@@ -0,0 +1,227 @@
+"""Builders of realistic fake models for unit tests of code using the SDK.
+
+Each builder returns a fully populated model that is consistent with itself:
+names contain the IDs, pull requests point at the session's repository and
+patches parse into hunks with the promised line counts. Keyword arguments
+override any model field.
+
+Example:
+    >>> from jules_agent_sdk.testing import fake_activity, fake_patch, fake_session
+    >>> session = fake_session(SessionState.COMPLETED, source="sources/github/octo/app")
+    >>> activity = fake_activity("planGenerated", session=session)
+    >>> patch = fake_patch(["app.py", "tests/test_app.py"], lines=5)
+"""
+
+import itertools
+from dataclasses import replace
+from datetime import datetime, timedelta, timezone
+from typing import Any, Dict, List, Optional, Sequence, Union
+
+from jules_agent_sdk.models import (
+    ACTIVITY_EVENT_KEYS,
+    Activity,
+    GitPatch,
+    Session,
+    SessionState,
+)
+
+FAKE_SOURCE = "sources/github/octo/app"
+FAKE_BRANCH = "main"
+FAKE_PROMPT = "Fix the failing unit tests in the payments module"
+
+# Timestamps of fakes start here and move forward one minute per model
+_EPOCH = datetime(2025, 1, 1, 9, 0, tzinfo=timezone.utc)
+_ids = itertools.count(1)
+
+
+def _next_id() -> int:
+    """Return a new number for IDs and timestamps."""
+    return next(_ids)
+
+
+def _timestamp(n: int) -> str:
+    """Return an RFC 3339 timestamp n minutes after the fake epoch."""
+    return (_EPOCH + timedelta(minutes=n)).strftime("%Y-%m-%dT%H:%M:%SZ")
+
+
+def _repo(source: str) -> str:
+    """Return "owner/repo" for a GitHub source name, or the last path segment."""
+    parts = source.split("/")
+    if len(parts) == 4 and parts[:2] == ["sources", "github"]:
+        return "/".join(parts[2:])
+    return parts[-1]
+
+
+def fake_session(
+    state: Union[SessionState, str] = SessionState.COMPLETED,
+    *,
+    session_id: Optional[str] = None,
+    prompt: str = FAKE_PROMPT,
+    source: str = FAKE_SOURCE,
+    starting_branch: str = FAKE_BRANCH,
+    pull_request: Optional[bool] = None,
+    **overrides: Any,
+) -> Session:
+    """Build a session in the given state.
+
+    Args:
+        state: Session state
+        session_id: Session ID (default: a new unique ID)
+        prompt: Prompt the session was created with; the title is derived from it
+        source: Source name of the session's repository
+        starting_branch: Branch the session started from
+        pull_request: Whether the session has a pull request output (default:
+            only COMPLETED sessions do)
+        **overrides: Session fields to set, e.g. title or require_plan_approval
+
+    Returns:
+        A Session as the API would return it
+    """
+    n = _next_id()
+    session_id = session_id or f"{14_000_000_000 + n}"
+    state = SessionState(state)
+    if pull_request is None:
+        pull_request = state == SessionState.COMPLETED
+
+    data: Dict[str, Any] = {
+        "name": f"sessions/{session_id}",
+        "id": session_id,
+        "prompt": prompt,
+        "title": prompt.splitlines()[0][:80] if prompt else "",
+        "sourceContext": {
+            "source": source,
+            "githubRepoContext": {"startingBranch": starting_branch},
+        },
+        "createTime": _timestamp(n),
+        "updateTime": _timestamp(n + 30),
+        "state": state.value,
+        "url": f"https://jules.google.com/session/{session_id}",
+    }
+    if pull_request:
+        data["outputs"] = [
+            {
+                "pullRequest": {
+                    "url": f"https://github.com/{_repo(source)}/pull/{n}",
+                    "title": data["title"],
+                    "description": f"Fixes the issue described in the prompt.\n\n{prompt}",
+                }
+            }
+        ]
+    return replace(Session.from_dict(data), **overrides)
+
+
+def fake_activity(
+    kind: str = "agentMessaged",
+    *,
+    session: Union[Session, str, None] = None,
+    activity_id: Optional[str] = None,
+    **overrides: Any,
+) -> Activity:
+    """Build an activity carrying one event.
+
+    Args:
+        kind: Event key, one of ACTIVITY_EVENT_KEYS, e.g. "planGenerated"
+        session: Session, or its name, the activity belongs to (default: a
+            new session name)
+        activity_id: Activity ID (default: a new unique ID)
+        **overrides: Activity fields to set, e.g. description or artifacts
+
+    Returns:
+        An Activity as the API would return it; sessionCompleted activities
+        carry a changeSet artifact with a fake_patch()
+
+    Raises:
+        ValueError: If kind is not a known event key
+    """
+    if kind not in ACTIVITY_EVENT_KEYS:
+        raise ValueError(f"Unknown activity kind {kind!r}; expected one of {ACTIVITY_EVENT_KEYS}")
+
+    n = _next_id()
+    activity_id = activity_id or f"act{n:06d}"
+    if isinstance(session, Session):
+        session_name, source = session.name, session.source_context.source
+    else:
+        session_name, source = session or f"sessions/{14_000_000_000 + n}", FAKE_SOURCE
+
+    plan_id = f"plan{n:06d}"
+    events: Dict[str, Dict[str, Any]] = {
+        "agentMessaged": {"agentMessage": "I found the cause of the failure and will fix it."},
+        "userMessaged": {"userMessage": "Please also add a regression test."},
+        "planGenerated": {
+            "plan": {
+                "id": plan_id,
+                "steps": [
+                    {
+                        "id": f"{plan_id}-{i}",
+                        "title": title,
+                        "description": f"{title} in the payments module.",
+                        "index": i,
+                    }
+                    for i, title in enumerate(
+                        ["Reproduce the failure", "Fix the bug", "Add a regression test"]
+                    )
+                ],
+                "createTime": _timestamp(n),
+            }
+        },
+        "planApproved": {"planId": plan_id},
+        "progressUpdated": {
+            "title": "Fix the bug",
+            "description": "Updated the rounding in the payments module.",
+        },
+        "sessionCompleted": {},
+        "sessionFailed": {"reason": "The agent could not reproduce the failure."},
+    }
+    data: Dict[str, Any] = {
+        "name": f"{session_name}/activities/{activity_id}",
+        "id": activity_id,
+        "createTime": _timestamp(n),
+        "originator": "user" if kind in ("userMessaged", "planApproved") else "agent",
+        kind: events[kind],
+    }
+    if kind == "sessionCompleted":
+        data["artifacts"] = [{"changeSet": {"source": source, "gitPatch": fake_patch().to_dict()}}]
+    return replace(Activity.from_dict(data), **overrides)
+
+
+def fake_patch(
+    files: Union[int, Sequence[str]] = 1,
+    lines: int = 3,
+    **overrides: Any,
+) -> GitPatch:
+    """Build a git patch adding lines to each of several files.
+
+    Args:
+        files: File paths, or a number of files to make up paths for
+        lines: Lines added to each file
+        **overrides: GitPatch fields to set, e.g. suggested_commit_message
+
+    Returns:
+        A GitPatch whose unidiff_patch has one hunk per file, each with
+        one context line and `lines` added lines
+    """
+    n = _next_id()
+    paths: List[str] = (
+        [f"src/module_{i}.py" for i in range(1, files + 1)]
+        if isinstance(files, int)
+        else list(files)
+    )
+    diff: List[str] = []
+    for path in paths:
+        diff += [
+            f"diff --git a/{path} b/{path}",
+            "index 3b18e51..a9c2f4e 100644",
+            f"--- a/{path}",
+            f"+++ b/{path}",
+            f"@@ -1,1 +1,{lines + 1} @@",
+            ' """Payments module."""',
+        ]
+        diff += [f"+ROUNDING_{i} = {i}" for i in range(1, lines + 1)]
+    return replace(
+        GitPatch(
+            unidiff_patch="\n".join(diff) + "\n" if diff else "",
+            base_commit_id=f"{n:040x}",
+            suggested_commit_message="Fix rounding in the payments module",
+        ),
+        **overrides,
+    )
@@ -0,0 +1,70 @@
+"""Client-side throttling for session creation."""
+
+import logging
+import threading
+import time
+from collections import deque
+from typing import Callable, Deque
+
+logger = logging.getLogger(__name__)
+
+# Length of the sliding window, in seconds
+CREATE_WINDOW = 60.0
+
+
+class CreateThrottle:
+    """Sliding-window limit on how many sessions a client starts per minute.
+
+    The service queues sessions, so starting hundreds at once degrades latency
+    for every user. Calls over the limit are delayed locally instead of failing.
+    The throttle is shared by all threads using the client.
+    """
+
+    def __init__(
+        self,
+        max_per_minute: int,
+        clock: Callable[[], float] = time.monotonic,
+    ) -> None:
+        """Initialize the throttle.
+
+        Args:
+            max_per_minute: Maximum creates started in any 60 second window
+            clock: Monotonic clock, replaceable in tests
+
+        Raises:
+            ValueError: If max_per_minute is not positive
+        """
+        if max_per_minute <= 0:
+            raise ValueError("max_per_minute must be positive")
+
+        self.max_per_minute = max_per_minute
+        self._clock = clock
+        self._slots: Deque[float] = deque()
+        self._lock = threading.Lock()
+
+    def reserve(self) -> float:
+        """Claim the next free create slot.
+
+        Returns:
+            Seconds the caller must wait before sending its create request
+        """
+        with self._lock:
+            now = self._clock()
+            while self._slots and self._slots[0] <= now - CREATE_WINDOW:
+                self._slots.popleft()
+
+            slot = now
+            if len(self._slots) >= self.max_per_minute:
+                slot = max(now, self._slots[-self.max_per_minute] + CREATE_WINDOW)
+            self._slots.append(slot)
+
+        delay = slot - now
+        if delay > 0:
+            logger.info(f"Session create throttled for {delay:.1f}s")
+        return delay
+
+    def wait(self) -> None:
+        """Block until the caller may create a session."""
+        delay = self.reserve()
+        if delay > 0:
+            time.sleep(delay)
@@ -0,0 +1,77 @@
+"""OpenTelemetry tracing for the sync client, as a middleware.
+
+Requires the optional opentelemetry-api package:
+
+    pip install "jules-agent-sdk[tracing]"
+"""
+
+from typing import Any, Optional
+from urllib.parse import urlsplit
+
+import requests
+
+from jules_agent_sdk.base import Doer, Middleware
+from jules_agent_sdk.context import current_attempt
+from jules_agent_sdk.resources import route
+
+TRACER_NAME = "jules_agent_sdk"
+
+
+def tracing_middleware(tracer: Optional[Any] = None) -> Middleware:
+    """Build a middleware that records a client span for every HTTP attempt.
+
+    Spans are named "METHOD /route", with IDs in the path replaced by {id} to
+    keep span names bounded, and carry the method, URL, raw path, response
+    status and retry attempt. The current trace context is injected into the
+    request headers, so the call joins the caller's trace across the mesh.
+
+    Args:
+        tracer: OpenTelemetry tracer to use (default: the global tracer
+            provider's tracer for "jules_agent_sdk")
+
+    Returns:
+        Middleware for JulesClient(middlewares=[...]) or client.use()
+
+    Raises:
+        ImportError: If opentelemetry-api is not installed
+
+    Example:
+        >>> from jules_agent_sdk.tracing import tracing_middleware
+        >>> client = JulesClient(api_key=key, middlewares=[tracing_middleware()])
+    """
+    try:
+        from opentelemetry import propagate, trace
+    except ImportError as e:
+        raise ImportError(
+            "tracing_middleware requires opentelemetry-api; "
+            'install it with pip install "jules-agent-sdk[tracing]"'
+        ) from e
+
+    tracer = tracer or trace.get_tracer(TRACER_NAME)
+
+    def middleware(next_doer: Doer) -> Doer:
+        def doer(method: str, url: str, **kwargs: Any) -> requests.Response:
+            path = urlsplit(url).path
+            with tracer.start_as_current_span(
+                f"{method} {route(path)}", kind=trace.SpanKind.CLIENT
+            ) as span:
+                span.set_attribute("http.request.method", method)
+                span.set_attribute("url.full", url)
+                span.set_attribute("url.path", path)
+                attempt = current_attempt()
+                if attempt is not None:
+                    span.set_attribute("http.request.resend_count", attempt - 1)
+
+                headers = dict(kwargs.get("headers") or {})
+                propagate.inject(headers)
+                kwargs["headers"] = headers
+
+                response = next_doer(method=method, url=url, **kwargs)
+                span.set_attribute("http.response.status_code", response.status_code)
+                if response.status_code >= 400:
+                    span.set_status(trace.Status(trace.StatusCode.ERROR))
+                return response
+
+        return doer
+
+    return middleware
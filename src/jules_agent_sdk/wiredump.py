@@ -0,0 +1,123 @@
+"""Dump full HTTP requests and responses for debugging, with secrets redacted."""
+
+import threading
+import time
+from typing import Any, Callable, Mapping, Optional, TextIO, Union
+from urllib.parse import parse_qsl, urlencode, urlsplit, urlunsplit
+
+import requests
+
+# Headers whose values never appear in a dump (compared case-insensitively)
+REDACTED_HEADERS = frozenset(
+    {"x-goog-api-key", "authorization", "proxy-authorization", "cookie", "set-cookie"}
+)
+
+# Query parameters whose values never appear in a dump
+REDACTED_PARAMS = frozenset({"key", "access_token"})
+
+REDACTED = "REDACTED"
+
+
+def redact_headers(headers: Mapping[str, str]) -> Mapping[str, str]:
+    """Return a copy of headers with credential values replaced by REDACTED."""
+    return {k: REDACTED if k.lower() in REDACTED_HEADERS else v for k, v in headers.items()}
+
+
+def redact_url(url: str) -> str:
+    """Return url with credential query parameters replaced by REDACTED."""
+    parts = urlsplit(url)
+    if not parts.query:
+        return url
+    query = [
+        (k, REDACTED if k.lower() in REDACTED_PARAMS else v)
+        for k, v in parse_qsl(parts.query, keep_blank_values=True)
+    ]
+    return urlunsplit(parts._replace(query=urlencode(query, safe=REDACTED)))
+
+
+def _text(body: Union[bytes, str, None]) -> str:
+    """Decode a request or response body for display."""
+    if body is None:
+        return ""
+    if isinstance(body, bytes):
+        return body.decode("utf-8", errors="replace")
+    return body
+
+
+def _block(first_line: str, headers: Mapping[str, str], body: Union[bytes, str, None]) -> str:
+    """Format a start line, redacted headers and body like an HTTP message."""
+    lines = [first_line]
+    lines += [f"{k}: {v}" for k, v in redact_headers(headers).items()]
+    text = _text(body)
+    if text:
+        lines += ["", text]
+    return "\n".join(lines) + "\n"
+
+
+class WireDumper:
+    """Writes every HTTP attempt of the sync client to a text stream.
+
+    Requests are dumped as actually sent, including session-wide headers, and
+    are followed by the response or the error that replaced it. Credential
+    headers and query parameters are redacted; bodies are written as-is.
+
+    Example:
+        >>> client = JulesClient(api_key=key, wire_dump=sys.stderr)
+    """
+
+    def __init__(self, fp: TextIO) -> None:
+        """Initialize the dumper.
+
+        Args:
+            fp: Text stream the dump is written to
+        """
+        self.fp = fp
+        self._lock = threading.Lock()
+
+    def write(self, text: str) -> None:
+        """Write one dump entry without interleaving with other threads."""
+        with self._lock:
+            self.fp.write(text)
+            self.fp.flush()
+
+    def middleware(
+        self, next_doer: Callable[..., requests.Response]
+    ) -> Callable[..., requests.Response]:
+        """Middleware dumping each attempt; the client installs it innermost."""
+
+        def doer(method: str, url: str, **kwargs: Any) -> requests.Response:
+            start = time.monotonic()
+            try:
+                response = next_doer(method=method, url=url, **kwargs)
+            except Exception as e:
+                self.write(self.format_failure(method, url, kwargs, e))
+                raise
+            self.write(self.format_exchange(response, time.monotonic() - start))
+            return response
+
+        return doer
+
+    @staticmethod
+    def format_exchange(response: requests.Response, elapsed: Optional[float] = None) -> str:
+        """Format a response and the prepared request that produced it."""
+        request = response.request
+        status = f"<<< {response.status_code} {response.reason or ''}".rstrip()
+        if elapsed is not None:
+            status += f" ({elapsed:.3f}s)"
+        start_line = f">>> {request.method} {redact_url(request.url or '')}"
+        sent = _block(start_line, request.headers, request.body)
+        return sent + _block(status, response.headers, response.content) + "\n"
+
+    @staticmethod
+    def format_failure(method: str, url: str, kwargs: Mapping[str, Any], error: Exception) -> str:
+        """Format a request that failed before a response arrived."""
+        request = requests.Request(
+            method,
+            url,
+            headers=kwargs.get("headers"),
+            params=kwargs.get("params"),
+            json=kwargs.get("json"),
+        ).prepare()
+        start_line = f">>> {method} {redact_url(request.url or url)}"
+        sent = _block(start_line, request.headers, request.body)
+        return sent + f"!!! {type(error).__name__}: {error}\n\n"
@@ -0,0 +1,20 @@
+"""Shared helpers for the test suite."""
+
+from unittest.mock import Mock
+
+
+def response(status, body=None, headers=None):
+    """Build a fake HTTP response.
+
+    Args:
+        status: HTTP status code
+        body: Decoded JSON body (default: a generic "unavailable" error)
+        headers: Response headers (default: none)
+    """
+    r = Mock()
+    r.ok = status < 400
+    r.status_code = status
+    r.headers = headers or {}
+    r.content = b"{}"
+    r.json.return_value = body or {"error": {"message": "unavailable"}}
+    return r
@@ -3,7 +3,11 @@
 import pytest
 from unittest.mock import AsyncMock, patch, MagicMock
 from jules_agent_sdk import AsyncJulesClient
-from jules_agent_sdk.exceptions import JulesAuthenticationError
+from jules_agent_sdk.exceptions import (
+    JulesAuthenticationError,
+    JulesNotFoundError,
+    JulesRateLimitError,
+)
 
 
 class TestAsyncJulesClient:
@@ -22,6 +26,19 @@ class TestAsyncJulesClient:
         with pytest.raises(ValueError, match="API key is required"):
             AsyncJulesClient(api_key="")
 
+    def test_async_client_connection_limits(self):
+        """Test connection limits are forwarded to the connector options."""
+        client = AsyncJulesClient(
+            api_key="test-api-key",
+            max_connections_per_host=50,
+            connector_kwargs={"keepalive_timeout": 30},
+        )
+        kwargs = client._base_client.connector_kwargs
+
+        assert kwargs["limit"] == 100
+        assert kwargs["limit_per_host"] == 50
+        assert kwargs["keepalive_timeout"] == 30
+
     @pytest.mark.asyncio
     async def test_async_client_context_manager(self):
         """Test async client works as context manager."""
@@ -48,6 +65,22 @@ class TestAsyncJulesClient:
         assert session.id == "test123"
         assert session.prompt == "Fix bug"
 
+    @pytest.mark.asyncio
+    @patch("jules_agent_sdk.async_base.AsyncBaseClient._request")
+    async def test_async_sessions_create_default_plan_approval(self, mock_request):
+        """Test async client-level plan approval default."""
+        mock_request.return_value = {
+            "name": "sessions/test123",
+            "id": "test123",
+            "prompt": "Fix bug",
+            "sourceContext": {"source": "sources/repo1"},
+        }
+
+        client = AsyncJulesClient(api_key="test-api-key", default_require_plan_approval=True)
+        await client.sessions.create(prompt="Fix bug", source="sources/repo1")
+
+        assert mock_request.call_args.kwargs["json"]["requirePlanApproval"] is True
+
     @pytest.mark.asyncio
     @patch("jules_agent_sdk.async_base.AsyncBaseClient._request")
     async def test_async_sessions_list(self, mock_request):
@@ -93,3 +126,158 @@ class TestAsyncJulesClient:
         assert len(activities) == 2
         assert activities[0].id == "a1"
         assert activities[1].id == "a2"
+
+    @pytest.mark.asyncio
+    @patch("jules_agent_sdk.async_base.AsyncBaseClient._request")
+    async def test_async_wait_for_completion_feedback_abort(self, mock_request):
+        """Test raising from the feedback handler aborts the async wait."""
+
+        def respond(method, path, params=None, json=None):
+            if path == "sessions/s1":
+                return {"id": "s1", "sourceContext": {}, "state": "AWAITING_USER_FEEDBACK"}
+            return {
+                "activities": [
+                    {
+                        "name": "sessions/s1/activities/a1",
+                        "agentMessaged": {"agentMessage": "Delete prod?"},
+                    }
+                ]
+            }
+
+        mock_request.side_effect = respond
+
+        async def refuse(session, message):
+            raise RuntimeError(f"Refusing: {message}")
+
+        client = AsyncJulesClient(api_key="test-api-key")
+        with pytest.raises(RuntimeError, match="Delete prod"):
+            await client.sessions.wait_for_completion(
+                "s1", poll_interval=0, on_feedback_requested=refuse
+            )
+
+    @pytest.mark.asyncio
+    @patch("jules_agent_sdk.async_base.AsyncBaseClient._request")
+    async def test_async_wait_for_completion_state_change_callbacks(self, mock_request):
+        """Test async state change callbacks are awaited and their errors counted."""
+        mock_request.return_value = {"id": "s1", "sourceContext": {}, "state": "COMPLETED"}
+        changes = []
+
+        async def record(session, previous):
+            changes.append((previous, session.state.value))
+
+        async def broken_notifier(session, previous):
+            raise RuntimeError("Slack is down")
+
+        client = AsyncJulesClient(api_key="test-api-key")
+        await client.sessions.wait_for_completion("s1", poll_interval=0, on_state_change=record)
+        assert changes == [(None, "COMPLETED")]
+
+        await client.sessions.wait_for_completion(
+            "s1", poll_interval=0, on_state_change=broken_notifier
+        )
+        assert client._base_client.callback_error_count == 1
+
+        strict = AsyncJulesClient(api_key="test-api-key", raise_callback_errors=True)
+        with pytest.raises(RuntimeError, match="Slack is down"):
+            await strict.sessions.wait_for_completion(
+                "s1", poll_interval=0, on_state_change=broken_notifier
+            )
+
+    @pytest.mark.asyncio
+    @patch("jules_agent_sdk.async_base.AsyncBaseClient._request")
+    async def test_async_wait_for_completion_cancel_during_not_found_grace(self, mock_request):
+        """Test cancelling during the not-found grace window stops the wait at once."""
+        import asyncio
+        from jules_agent_sdk.exceptions import WaitCancelledError
+
+        mock_request.side_effect = JulesNotFoundError("not found", 404)
+        cancel = asyncio.Event()
+        asyncio.get_event_loop().call_later(0.01, cancel.set)
+
+        client = AsyncJulesClient(api_key="test-api-key")
+        with pytest.raises(WaitCancelledError):
+            await asyncio.wait_for(
+                client.sessions.wait_for_completion(
+                    "s1", poll_interval=30, not_found_grace=60, cancel_event=cancel
+                ),
+                5,
+            )
+
+    @pytest.mark.asyncio
+    @patch("jules_agent_sdk.async_base.asyncio.sleep")
+    @patch("jules_agent_sdk.async_base.AsyncBaseClient._get_session")
+    async def test_async_rate_limit_retry_after(self, mock_get_session, mock_sleep):
+        """Test a 429 is retried after the Retry-After delay, up to max_retry_after."""
+
+        def response(status, retry_after=None):
+            r = MagicMock()
+            r.ok = status < 400
+            r.status = status
+            r.headers = {"Retry-After": retry_after} if retry_after else {}
+            r.content_length = 2
+            r.text = AsyncMock(return_value='{"name": "sessions/s1"}')
+            context = MagicMock()
+            context.__aenter__ = AsyncMock(return_value=r)
+            context.__aexit__ = AsyncMock(return_value=False)
+            return context
+
+        session = MagicMock()
+        mock_get_session.return_value = session
+        client = AsyncJulesClient(api_key="test-api-key")
+
+        session.request.side_effect = [response(429, "7"), response(200)]
+        assert (await client.sessions.get("s1")).name == "sessions/s1"
+        mock_sleep.assert_called_once_with(7.0)
+
+        session.request.side_effect = [response(429, "120")]
+        with pytest.raises(JulesRateLimitError) as exc_info:
+            await client.sessions.get("s1")
+        assert exc_info.value.attempts == 1
+
+        no_retry = AsyncJulesClient(api_key="test-api-key", max_retry_after=0)
+        session.request.side_effect = [response(429, "1")]
+        with pytest.raises(JulesRateLimitError):
+            await no_retry.sessions.get("s1")
+        assert session.request.call_count == 4
+
+    @pytest.mark.asyncio
+    async def test_async_html_error_response(self):
+        """Test async error handling reports non-JSON bodies with a typed error."""
+        from jules_agent_sdk.async_base import AsyncBaseClient
+        from jules_agent_sdk.exceptions import UnexpectedContentTypeError
+
+        response = MagicMock()
+        response.status = 503
+        response.headers = {"Content-Type": "text/html"}
+        response.text = AsyncMock(return_value="<html>Service Unavailable</html>")
+
+        client = AsyncBaseClient(api_key="test-api-key")
+        with pytest.raises(UnexpectedContentTypeError) as exc_info:
+            await client._handle_error(response)
+
+        assert exc_info.value.status_code == 503
+        assert "Service Unavailable" in exc_info.value.body_snippet
+
+        response.status = 404
+        with pytest.raises(JulesNotFoundError):
+            await client._handle_error(response)
+
+        response.status = 429
+        response.headers = {"Content-Type": "text/plain", "Retry-After": "7"}
+        response.text = AsyncMock(return_value="Too Many Requests")
+        with pytest.raises(JulesRateLimitError) as exc_info:
+            await client._handle_error(response)
+        assert exc_info.value.response["retry_after_seconds"] == 7
+
+    @pytest.mark.asyncio
+    @patch("jules_agent_sdk.async_base.AsyncBaseClient._get_session")
+    async def test_async_read_only(self, mock_get_session):
+        """Test read-only async clients reject mutating calls before connecting."""
+        from jules_agent_sdk import ReadOnlyModeError
+
+        client = AsyncJulesClient(api_key="test-api-key", read_only=True)
+        with pytest.raises(ReadOnlyModeError) as exc_info:
+            await client.sessions.approve_plan("s1")
+
+        assert exc_info.value.operation == "POST sessions/s1:approvePlan"
+        mock_get_session.assert_not_called()
@@ -0,0 +1,119 @@
+"""Tests for the circuit breaker."""
+
+from unittest.mock import patch
+
+import pytest
+import requests
+
+from jules_agent_sdk.circuit import CLOSED, HALF_OPEN, OPEN, CircuitBreaker
+from jules_agent_sdk.client import JulesClient
+from jules_agent_sdk.exceptions import CircuitOpenError, is_retryable, retry_delay_hint
+from tests.helpers import response
+
+ENDPOINT = "https://jules.googleapis.com/v1alpha"
+
+
+class FakeClock:
+    """Manually advanced monotonic clock."""
+
+    def __init__(self):
+        self.now = 100.0
+
+    def __call__(self):
+        return self.now
+
+
+class TestCircuitBreaker:
+    """Test cases for CircuitBreaker."""
+
+    def test_opens_and_recovers(self):
+        """Test the circuit opens at the threshold, then closes after a good trial."""
+        clock = FakeClock()
+        breaker = CircuitBreaker(failure_threshold=2, cooldown=30, clock=clock)
+
+        breaker.record_failure(ENDPOINT)
+        breaker.before_request(ENDPOINT)
+        breaker.record_failure(ENDPOINT)
+        assert breaker.state(ENDPOINT) == OPEN
+
+        with pytest.raises(CircuitOpenError) as exc_info:
+            breaker.before_request(ENDPOINT)
+        assert exc_info.value.retry_after == 30
+        assert retry_delay_hint(exc_info.value) == 30
+        assert is_retryable(exc_info.value)
+
+        clock.now += 30
+        breaker.before_request(ENDPOINT)
+        assert breaker.state(ENDPOINT) == HALF_OPEN
+        with pytest.raises(CircuitOpenError):
+            breaker.before_request(ENDPOINT)
+
+        breaker.record_success(ENDPOINT)
+        assert breaker.state(ENDPOINT) == CLOSED
+        breaker.before_request(ENDPOINT)
+
+    def test_failed_trial_reopens(self):
+        """Test a failed trial request opens the circuit for another cool-down."""
+        clock = FakeClock()
+        breaker = CircuitBreaker(failure_threshold=1, cooldown=10, clock=clock)
+        breaker.record_failure(ENDPOINT)
+
+        clock.now += 10
+        breaker.before_request(ENDPOINT)
+        clock.now += 2
+        breaker.record_failure(ENDPOINT)
+
+        assert breaker.state(ENDPOINT) == OPEN
+        with pytest.raises(CircuitOpenError) as exc_info:
+            breaker.before_request(ENDPOINT)
+        assert exc_info.value.retry_after == 10
+
+    def test_success_resets_failures(self):
+        """Test failures must be consecutive to open the circuit."""
+        breaker = CircuitBreaker(failure_threshold=2)
+        breaker.record_failure(ENDPOINT)
+        breaker.record_success(ENDPOINT)
+        breaker.record_failure(ENDPOINT)
+        assert breaker.state(ENDPOINT) == CLOSED
+
+    @patch("jules_agent_sdk.base.time.sleep")
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_client_fails_fast(self, mock_request, mock_sleep):
+        """Test the client stops sending requests once the circuit opens."""
+        mock_request.side_effect = [
+            response(503),
+            requests.ConnectionError("connection refused"),
+        ]
+        breaker = CircuitBreaker(failure_threshold=2, cooldown=60)
+        client = JulesClient(api_key="test-key", max_retries=5, circuit_breaker=breaker)
+
+        with pytest.raises(CircuitOpenError) as exc_info:
+            client.sessions.get("s1")
+        assert mock_request.call_count == 2
+        assert exc_info.value.attempts == 2
+        assert isinstance(exc_info.value.__cause__, requests.ConnectionError)
+
+        with pytest.raises(CircuitOpenError):
+            client.sessions.get("s1")
+        assert mock_request.call_count == 2
+        assert client._base_client.get_stats()["circuit_rejections"] == 2
+
+    @patch("jules_agent_sdk.base.time.sleep")
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_open_circuit_fails_over(self, mock_request, mock_sleep):
+        """Test an open circuit moves the client to the next base URL."""
+        mock_request.side_effect = [
+            response(503),
+            response(200, {"name": "sessions/s1"}),
+        ]
+        breaker = CircuitBreaker(failure_threshold=1)
+        client = JulesClient(
+            api_key="test-key",
+            base_url="https://primary/v1alpha",
+            fallback_base_urls=["https://secondary/v1alpha"],
+            circuit_breaker=breaker,
+        )
+
+        assert client.sessions.get("s1").name == "sessions/s1"
+        assert mock_request.call_args.kwargs["url"].startswith("https://secondary/")
+        assert breaker.state("https://primary/v1alpha") == OPEN
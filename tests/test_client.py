@@ -1,9 +1,24 @@
 """Tests for the Jules client."""
 
 import pytest
+from datetime import datetime, timedelta, timezone
 from unittest.mock import Mock, patch, MagicMock
 from jules_agent_sdk import JulesClient
-from jules_agent_sdk.exceptions import JulesAuthenticationError, JulesValidationError
+from jules_agent_sdk.exceptions import (
+    JulesAPIError,
+    JulesAuthenticationError,
+    JulesNotFoundError,
+    JulesRateLimitError,
+    JulesTimeoutError,
+    JulesValidationError,
+    PlanSupersededError,
+    SessionStalledError,
+    UnexpectedContentTypeError,
+    is_retryable,
+)
+from jules_agent_sdk.models import Session, SessionState
+from jules_agent_sdk.retry import NoRetry
+from tests.helpers import response
 
 
 class TestJulesClient:
@@ -47,6 +62,52 @@ class TestJulesClient:
         assert session.prompt == "Fix bug"
         mock_request.assert_called_once()
 
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_sessions_create_default_plan_approval(self, mock_request):
+        """Test client-level plan approval default applies unless overridden."""
+        mock_request.return_value = {
+            "name": "sessions/test123",
+            "id": "test123",
+            "prompt": "Fix bug",
+            "sourceContext": {"source": "sources/repo1"},
+        }
+
+        client = JulesClient(api_key="test-api-key", default_require_plan_approval=True)
+        client.sessions.create(prompt="Fix bug", source="sources/repo1")
+        assert mock_request.call_args.kwargs["json"]["requirePlanApproval"] is True
+
+        client.sessions.create(
+            prompt="Fix bug", source="sources/repo1", require_plan_approval=False
+        )
+        assert "requirePlanApproval" not in mock_request.call_args.kwargs["json"]
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_sessions_create_interceptors(self, mock_request):
+        """Test create interceptors can mutate or reject requests."""
+        mock_request.return_value = {
+            "name": "sessions/test123",
+            "id": "test123",
+            "prompt": "Fix bug",
+            "sourceContext": {"source": "sources/repo1"},
+        }
+
+        def prefix_title(request):
+            request["title"] = "[bot] " + request.get("title", "")
+
+        def forbid_source(request):
+            if request["sourceContext"]["source"] == "sources/forbidden":
+                raise ValueError("Source is not allowed")
+
+        client = JulesClient(api_key="test-api-key", create_interceptors=[prefix_title])
+        client.sessions.add_create_interceptor(forbid_source)
+
+        client.sessions.create(prompt="Fix bug", source="sources/repo1", title="Fix")
+        assert mock_request.call_args.kwargs["json"]["title"] == "[bot] Fix"
+
+        with pytest.raises(ValueError, match="not allowed"):
+            client.sessions.create(prompt="Fix bug", source="sources/forbidden")
+        assert mock_request.call_count == 1
+
     @patch("jules_agent_sdk.base.BaseClient._request")
     def test_sessions_get(self, mock_request):
         """Test getting a session."""
@@ -91,6 +152,492 @@ class TestJulesClient:
         assert len(result["sessions"]) == 2
         assert result["nextPageToken"] == "next-page"
 
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_wait_for_completion_answers_feedback(self, mock_request):
+        """Test wait_for_completion replies to agent questions via the handler."""
+        states = iter(["AWAITING_USER_FEEDBACK", "AWAITING_USER_FEEDBACK", "COMPLETED"])
+
+        def respond(method, path, params=None, json=None):
+            if path == "sessions/s1":
+                return {"id": "s1", "sourceContext": {}, "state": next(states)}
+            if path == "sessions/s1/activities":
+                return {
+                    "activities": [
+                        {
+                            "name": "sessions/s1/activities/a1",
+                            "createTime": "2024-01-01T00:00:00Z",
+                            "agentMessaged": {"agentMessage": "Which branch?"},
+                        }
+                    ]
+                }
+            return {}
+
+        mock_request.side_effect = respond
+        questions = []
+
+        def answer(session, message):
+            questions.append(message)
+            return "Use main"
+
+        client = JulesClient(api_key="test-api-key")
+        session = client.sessions.wait_for_completion(
+            "s1", poll_interval=0, on_feedback_requested=answer
+        )
+
+        assert session.state.value == "COMPLETED"
+        assert questions == ["Which branch?"]
+        mock_request.assert_any_call(
+            "POST", "sessions/s1:sendMessage", params=None, json={"prompt": "Use main"}
+        )
+
+    @patch("jules_agent_sdk.sessions.time.time")
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_wait_for_completion_timeout(self, mock_request, mock_time):
+        """Test wait timeouts carry the session name and elapsed time."""
+        mock_request.return_value = {
+            "name": "sessions/s1",
+            "id": "s1",
+            "sourceContext": {},
+            "state": "IN_PROGRESS",
+        }
+        mock_time.side_effect = [100.0, 161.5]
+
+        client = JulesClient(api_key="test-api-key")
+        with pytest.raises(TimeoutError) as exc_info:
+            client.sessions.wait_for_completion("s1", poll_interval=0, timeout=60)
+
+        assert isinstance(exc_info.value, JulesTimeoutError)
+        assert exc_info.value.session_id == "sessions/s1"
+        assert exc_info.value.elapsed == 61.5
+
+    @patch("jules_agent_sdk.sessions.time.sleep")
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_wait_for_completion_resume(self, mock_request, mock_sleep):
+        """Test resuming reports only transitions since the last known state."""
+        states = iter(["IN_PROGRESS", "IN_PROGRESS", "COMPLETED"])
+        mock_request.side_effect = lambda *a, **k: {
+            "id": "s1",
+            "sourceContext": {},
+            "state": next(states),
+        }
+        transitions = []
+
+        client = JulesClient(api_key="test-api-key")
+        client.sessions.wait_for_completion(
+            "s1",
+            poll_interval=0,
+            last_known_state=SessionState.PLANNING,
+            on_state_change=lambda s, prev: transitions.append((prev, s.state)),
+        )
+
+        assert transitions == [
+            (SessionState.PLANNING, SessionState.IN_PROGRESS),
+            (SessionState.IN_PROGRESS, SessionState.COMPLETED),
+        ]
+
+    @patch("jules_agent_sdk.sessions.time.time")
+    @patch("jules_agent_sdk.sessions.time.sleep")
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_wait_for_completion_queued_stall(self, mock_request, mock_sleep, mock_time):
+        """Test a session stuck in QUEUED raises or is replaced by the stall handler."""
+        mock_time.side_effect = iter(range(0, 10000, 10))
+        mock_request.side_effect = lambda method, path, **k: {
+            "name": path,
+            "sourceContext": {},
+            "state": "COMPLETED" if path == "sessions/s2" else "QUEUED",
+        }
+        client = JulesClient(api_key="test-api-key")
+
+        with pytest.raises(SessionStalledError) as exc_info:
+            client.sessions.wait_for_completion("s1", poll_interval=0, queued_timeout=30)
+        assert exc_info.value.session_id == "sessions/s1"
+        assert exc_info.value.stalled_for > 30
+
+        stalls = []
+
+        def recreate(session, queued_for):
+            stalls.append(session.name)
+            return Session(prompt="p", source_context=None, name="sessions/s2")
+
+        final = client.sessions.wait_for_completion(
+            "s1", poll_interval=0, queued_timeout=30, on_queued_stall=recreate
+        )
+        assert stalls == ["sessions/s1"]
+        assert final.name == "sessions/s2"
+
+    @patch("jules_agent_sdk.sessions.time.time")
+    @patch("jules_agent_sdk.sessions.time.sleep")
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_wait_for_completion_max_inactivity(self, mock_request, mock_sleep, mock_time):
+        """Test an IN_PROGRESS session whose updateTime stops changing is reported."""
+        mock_time.side_effect = iter(range(0, 10000, 10))
+        update_times = iter(["t1", "t2", "t2", "t2", "t2", "t2", "t2"])
+        mock_request.side_effect = lambda *a, **k: {
+            "name": "sessions/s1",
+            "sourceContext": {},
+            "state": "IN_PROGRESS",
+            "updateTime": next(update_times),
+        }
+        client = JulesClient(api_key="test-api-key")
+
+        with pytest.raises(SessionStalledError) as exc_info:
+            client.sessions.wait_for_completion("s1", poll_interval=0, max_inactivity=25)
+        assert exc_info.value.state == "IN_PROGRESS"
+        assert mock_request.call_count == 3
+
+        states = iter(["IN_PROGRESS"] * 6 + ["COMPLETED"])
+        mock_request.side_effect = lambda *a, **k: {
+            "name": "sessions/s1",
+            "sourceContext": {},
+            "state": next(states),
+            "updateTime": "t1",
+        }
+        inactive = []
+        client.sessions.wait_for_completion(
+            "s1",
+            poll_interval=0,
+            max_inactivity=25,
+            on_inactive=lambda s, inactive_for: inactive.append(inactive_for),
+        )
+        assert len(inactive) == 3
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_wait_for_completion_cancel_event(self, mock_request):
+        """Test setting cancel_event stops the wait without further polling."""
+        import threading
+        from jules_agent_sdk import WaitCancelledError
+
+        mock_request.return_value = {"name": "sessions/s1", "sourceContext": {}, "state": "QUEUED"}
+        cancel = threading.Event()
+
+        client = JulesClient(api_key="test-api-key")
+        with pytest.raises(WaitCancelledError) as exc_info:
+            client.sessions.wait_for_completion(
+                "s1",
+                poll_interval=60,
+                cancel_event=cancel,
+                on_state_change=lambda s, prev: cancel.set(),
+            )
+
+        assert exc_info.value.session_id == "sessions/s1"
+        assert exc_info.value.reason is None
+        assert mock_request.call_count == 1
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_wait_for_completion_cancel_reason(self, mock_request):
+        """Test the reason recorded on a CancelEvent is attached to the error."""
+        from jules_agent_sdk import CancelEvent, CancelReason, WaitCancelledError
+
+        mock_request.return_value = {"name": "sessions/s1", "sourceContext": {}, "state": "QUEUED"}
+        cancel = CancelEvent()
+        cancel.cancel(CancelReason.POLICY)
+        cancel.cancel("ignored, the first reason wins")
+
+        client = JulesClient(api_key="test-api-key")
+        with pytest.raises(WaitCancelledError) as exc_info:
+            client.sessions.wait_for_completion("s1", cancel_event=cancel)
+
+        assert exc_info.value.reason == "POLICY"
+        assert "(POLICY)" in str(exc_info.value)
+        mock_request.assert_not_called()
+
+    @patch("jules_agent_sdk.sessions.time.sleep")
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_wait_for_completion_stats_and_max_polls(self, mock_request, mock_sleep):
+        """Test a wait reports the polls and requests it used and honours max_polls."""
+        from jules_agent_sdk import PollLimitExceededError, WaitStats
+
+        states = iter(["AWAITING_USER_FEEDBACK", "COMPLETED"])
+        message = {"name": "sessions/s1/activities/a1", "agentMessaged": {"agentMessage": "?"}}
+
+        def respond(**kwargs):
+            response = Mock()
+            response.ok = True
+            response.status_code = 200
+            if kwargs["url"].endswith("/activities"):
+                response.json.return_value = {"activities": [message]}
+            elif kwargs["method"] == "POST":
+                response.json.return_value = {}
+            else:
+                response.json.return_value = {
+                    "name": "sessions/s1",
+                    "sourceContext": {},
+                    "state": next(states),
+                }
+            return response
+
+        mock_request.side_effect = respond
+        client = JulesClient(api_key="test-api-key")
+
+        stats = WaitStats()
+        client.sessions.wait_for_completion(
+            "s1", poll_interval=0, on_feedback_requested=lambda s, m: "main", stats=stats
+        )
+        assert (stats.polls, stats.requests) == (2, 4)
+        assert stats.elapsed >= 0
+
+        states = iter(["QUEUED"] * 5)
+        stats = WaitStats()
+        with pytest.raises(PollLimitExceededError) as exc_info:
+            client.sessions.wait_for_completion("s1", poll_interval=0, max_polls=3, stats=stats)
+        assert exc_info.value.max_polls == 3
+        assert (stats.polls, stats.requests) == (3, 3)
+
+    @patch("jules_agent_sdk.sessions.time.time")
+    @patch("jules_agent_sdk.sessions.time.sleep")
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_wait_for_completion_not_found_grace(self, mock_request, mock_sleep, mock_time):
+        """Test early 404s are tolerated only within the grace window."""
+        mock_time.side_effect = iter(range(0, 10000, 5))
+        completed = {"name": "sessions/s1", "sourceContext": {}, "state": "COMPLETED"}
+        responses = iter([JulesNotFoundError("not found", 404)] * 2 + [completed])
+
+        def respond(*args, **kwargs):
+            response = next(responses)
+            if isinstance(response, Exception):
+                raise response
+            return response
+
+        mock_request.side_effect = respond
+        client = JulesClient(api_key="test-api-key")
+
+        session = client.sessions.wait_for_completion("s1", poll_interval=0, not_found_grace=30)
+        assert session.state == SessionState.COMPLETED
+
+        mock_request.side_effect = JulesNotFoundError("not found", 404)
+        with pytest.raises(JulesNotFoundError):
+            client.sessions.wait_for_completion("s1", poll_interval=0, not_found_grace=30)
+        with pytest.raises(JulesNotFoundError):
+            client.sessions.wait_for_completion("s1", poll_interval=0)
+
+    @patch("jules_agent_sdk.sessions.time.sleep")
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_approve_plan_conflicts(self, mock_request, mock_sleep):
+        """Test conflicting approvals are retried or reported as superseded."""
+        conflict = JulesAPIError("plan is being regenerated", 409)
+        awaiting = {"name": "sessions/s1", "state": "AWAITING_PLAN_APPROVAL"}
+
+        def plans(*plan_ids):
+            return {
+                "activities": [
+                    {"name": f"sessions/s1/activities/{i}", "planGenerated": {"plan": {"id": i}}}
+                    for i in plan_ids
+                ]
+            }
+
+        client = JulesClient(api_key="test-api-key")
+
+        mock_request.side_effect = [conflict, awaiting, plans("p1"), {}]
+        client.sessions.approve_plan("s1", plan_id="p1")
+        assert mock_request.call_args[0] == ("POST", "sessions/s1:approvePlan")
+        mock_sleep.assert_called_once_with(1.0)
+
+        mock_request.side_effect = [conflict, awaiting, plans("p1", "p2")]
+        with pytest.raises(PlanSupersededError) as exc_info:
+            client.sessions.approve_plan("s1", plan_id="p1")
+        assert exc_info.value.plan.id == "p2"
+        assert exc_info.value.plan_id == "p1"
+
+        mock_request.side_effect = [conflict]
+        with pytest.raises(JulesAPIError) as exc_info:
+            client.sessions.approve_plan("s1")
+        assert exc_info.value is conflict
+
+        # The conflict backoff does not depend on the transport retry policy
+        mock_sleep.reset_mock()
+        mock_request.side_effect = [conflict, awaiting, plans("p1"), {}]
+        no_retry = JulesClient(api_key="test-api-key", retry_policy=NoRetry())
+        no_retry.sessions.approve_plan("s1", plan_id="p1")
+        mock_sleep.assert_called_once_with(1.0)
+
+    @patch("jules_agent_sdk.sessions.time.time")
+    @patch("jules_agent_sdk.sessions.time.sleep")
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_approve_plan_and_wait(self, mock_request, mock_sleep, mock_time):
+        """Test the wait starts only once the approval has taken effect."""
+        from jules_agent_sdk import SessionStalledError
+
+        mock_time.side_effect = iter(range(0, 10000, 20))
+
+        def session(state):
+            return {"name": "sessions/s1", "sourceContext": {}, "state": state}
+
+        def plans(plan_id):
+            return {"activities": [{"name": "a", "planGenerated": {"plan": {"id": plan_id}}}]}
+
+        awaiting = session("AWAITING_PLAN_APPROVAL")
+        client = JulesClient(api_key="test-api-key")
+        changes = []
+
+        mock_request.side_effect = [
+            {},
+            awaiting,
+            session("IN_PROGRESS"),
+            session("COMPLETED"),
+        ]
+        final = client.sessions.approve_plan_and_wait(
+            "s1", poll_interval=0, on_state_change=lambda s, prev: changes.append(prev)
+        )
+        assert final.state == SessionState.COMPLETED
+        assert mock_request.call_args_list[0][0] == ("POST", "sessions/s1:approvePlan")
+        assert changes == [SessionState.AWAITING_PLAN_APPROVAL]
+
+        mock_request.side_effect = [{}, awaiting, plans("p2"), session("COMPLETED")]
+        final = client.sessions.approve_plan_and_wait("s1", plan_id="p1", poll_interval=0)
+        assert final.state == SessionState.COMPLETED
+
+        mock_request.side_effect = [{}] + [awaiting, plans("p1")] * 10
+        with pytest.raises(SessionStalledError) as exc_info:
+            client.sessions.approve_plan_and_wait("s1", plan_id="p1", transition_timeout=30)
+        assert exc_info.value.state == "AWAITING_PLAN_APPROVAL"
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_sessions_artifacts(self, mock_request):
+        """Test the artifact index loads lazily, once, and groups by type."""
+        patch_artifact = {"changeSet": {"source": "sources/r", "gitPatch": {"unidiffPatch": ""}}}
+        mock_request.return_value = {
+            "activities": [
+                {
+                    "name": "sessions/s1/activities/a1",
+                    "artifacts": [
+                        {"bashOutput": {"command": "make test", "output": "", "exitCode": 1}},
+                        patch_artifact,
+                    ],
+                },
+                {"name": "sessions/s1/activities/a2", "agentMessaged": {"agentMessage": "hi"}},
+                {
+                    "name": "sessions/s1/activities/a3",
+                    "artifacts": [{"media": {"data": "", "mimeType": "image/png"}}, patch_artifact],
+                },
+            ]
+        }
+
+        client = JulesClient(api_key="test-api-key")
+        artifacts = client.sessions.artifacts("s1")
+        assert not artifacts.loaded
+        mock_request.assert_not_called()
+
+        assert artifacts.counts() == {"changeSet": 2, "media": 1, "bashOutput": 1}
+        assert [o.command for o in artifacts.bash_outputs] == ["make test"]
+        assert artifacts.media[0].mime_type == "image/png"
+        assert artifacts.by_type("changeSet")[-1].activity.name == "sessions/s1/activities/a3"
+        assert artifacts.latest_change_set is artifacts.change_sets[-1]
+        assert len(artifacts) == 4
+        assert mock_request.call_count == 1
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_sessions_last_agent_message(self, mock_request):
+        """Test last_agent_message returns the newest agent message."""
+        mock_request.return_value = {
+            "activities": [
+                {
+                    "name": "sessions/s1/activities/a1",
+                    "createTime": "2024-01-01T00:00:00Z",
+                    "agentMessaged": {"agentMessage": "First question"},
+                },
+                {
+                    "name": "sessions/s1/activities/a2",
+                    "createTime": "2024-01-01T00:05:00Z",
+                    "agentMessaged": {"agentMessage": "Second question"},
+                },
+                {
+                    "name": "sessions/s1/activities/a3",
+                    "createTime": "2024-01-01T00:06:00Z",
+                    "userMessaged": {"userMessage": "Answer"},
+                },
+            ]
+        }
+
+        client = JulesClient(api_key="test-api-key")
+        message, sent_at = client.sessions.last_agent_message("s1")
+
+        assert message == "Second question"
+        assert sent_at == datetime(2024, 1, 1, 0, 5, tzinfo=timezone.utc)
+
+        mock_request.return_value = {"activities": []}
+        assert client.sessions.last_agent_message("s1") == ("", None)
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_sessions_get_completion_details(self, mock_request):
+        """Test completion details combine session outputs and the completion activity."""
+
+        def respond(method, path, params=None, json=None):
+            if path == "sessions/s1":
+                return {
+                    "name": "sessions/s1",
+                    "sourceContext": {},
+                    "state": "COMPLETED",
+                    "outputs": [
+                        {"pullRequest": {"url": "https://github.com/o/r/pull/1", "title": "Fix"}}
+                    ],
+                }
+            return {
+                "activities": [
+                    {
+                        "name": "sessions/s1/activities/a1",
+                        "agentMessaged": {"agentMessage": "Fixed the login bug"},
+                    },
+                    {
+                        "name": "sessions/s1/activities/a2",
+                        "createTime": "2024-01-01T01:00:00Z",
+                        "sessionCompleted": {"filesChanged": 2},
+                        "artifacts": [
+                            {"changeSet": {"source": "sources/repo1", "gitPatch": {}}}
+                        ],
+                    },
+                ]
+            }
+
+        mock_request.side_effect = respond
+        client = JulesClient(api_key="test-api-key")
+        details = client.sessions.get_completion_details("s1")
+
+        assert details.session.state.value == "COMPLETED"
+        assert details.completed_time == "2024-01-01T01:00:00Z"
+        assert details.summary == "Fixed the login bug"
+        assert details.pull_requests[0].url == "https://github.com/o/r/pull/1"
+        assert details.change_sets[0].source == "sources/repo1"
+        assert details.stats == {"filesChanged": 2}
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_sessions_retry_failed(self, mock_request):
+        """Test retry_failed recreates the session with the failure reason appended."""
+        state = {"value": "FAILED"}
+
+        def respond(method, path, params=None, json=None):
+            if method == "POST":
+                return {"name": "sessions/s2", "id": "s2", **json}
+            if path == "sessions/s1":
+                return {
+                    "name": "sessions/s1",
+                    "prompt": "Fix bug",
+                    "title": "Bug fix",
+                    "requirePlanApproval": True,
+                    "sourceContext": {
+                        "source": "sources/repo1",
+                        "githubRepoContext": {"startingBranch": "develop"},
+                    },
+                    "state": state["value"],
+                }
+            return {"activities": [{"name": "a1", "sessionFailed": {"reason": "Tests failed"}}]}
+
+        mock_request.side_effect = respond
+        client = JulesClient(api_key="test-api-key")
+        retry = client.sessions.retry_failed("s1")
+
+        request = mock_request.call_args.kwargs["json"]
+        assert retry.id == "s2"
+        assert request["prompt"] == (
+            "Fix bug\n\nA previous attempt (sessions/s1) failed: Tests failed"
+        )
+        assert request["sourceContext"]["githubRepoContext"]["startingBranch"] == "develop"
+        assert request["title"] == "Bug fix"
+        assert request["requirePlanApproval"] is True
+
+        state["value"] = "COMPLETED"
+        with pytest.raises(JulesValidationError, match="COMPLETED"):
+            client.sessions.retry_failed("s1")
+
     @patch("jules_agent_sdk.base.BaseClient._request")
     def test_activities_list(self, mock_request):
         """Test listing activities."""
@@ -115,6 +662,286 @@ class TestJulesClient:
         assert len(result["activities"]) == 2
         assert result["activities"][0].id == "a1"
 
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_activities_page_size_defaults_and_cap(self, mock_request):
+        """Test client default page size applies and oversized pages are clamped."""
+        mock_request.return_value = {"activities": []}
+
+        client = JulesClient(api_key="test-api-key", default_page_size=50)
+        client.activities.list_all("s1")
+        assert mock_request.call_args.kwargs["params"]["pageSize"] == 50
+
+        client.activities.list_all("s1", page_size=500)
+        assert mock_request.call_args.kwargs["params"]["pageSize"] == 100
+
+        client.activities.list("s1", page_size=10)
+        assert mock_request.call_args.kwargs["params"]["pageSize"] == 10
+
+    @pytest.mark.parametrize(
+        "call",
+        [
+            lambda c: c.sessions.list(page_size=-1),
+            lambda c: c.activities.list("s1", page_size="10"),
+            lambda c: c.sources.list(page_size=True),
+            lambda c: c.sessions.list(page_token="abc def"),
+            lambda c: c.sources.list(page_token="abc\n"),
+        ],
+    )
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_list_options_validation(self, mock_request, call):
+        """Test invalid page sizes and tokens are rejected before any request."""
+        client = JulesClient(api_key="test-api-key")
+        with pytest.raises(JulesValidationError):
+            call(client)
+        mock_request.assert_not_called()
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_list_all_pagination_safety(self, mock_request):
+        """Test list_all stops on repeated tokens and on the page limit."""
+        from jules_agent_sdk import PaginationLoopError
+
+        mock_request.return_value = {"activities": [], "nextPageToken": "same"}
+        client = JulesClient(api_key="test-api-key")
+
+        with pytest.raises(PaginationLoopError, match="twice") as exc_info:
+            client.activities.list_all("s1")
+        assert exc_info.value.pages == 2
+
+        pages = iter(range(10))
+        mock_request.side_effect = lambda *a, **k: {
+            "sources": [],
+            "nextPageToken": f"t{next(pages)}",
+        }
+        with pytest.raises(PaginationLoopError, match="3 pages"):
+            client.sources.list_all(max_pages=3)
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_strict_ids(self, mock_request):
+        """Test URLs are normalized by default and rejected in strict mode."""
+        from jules_agent_sdk import InvalidResourceNameError
+
+        mock_request.return_value = {"name": "sessions/42", "id": "42"}
+
+        client = JulesClient(api_key="test-api-key")
+        client.sessions.get("https://jules.google.com/session/42")
+        assert mock_request.call_args[0] == ("GET", "sessions/42")
+
+        strict = JulesClient(api_key="test-api-key", strict_ids=True)
+        with pytest.raises(InvalidResourceNameError):
+            strict.sessions.get("https://jules.google.com/session/42")
+        with pytest.raises(InvalidResourceNameError):
+            strict.activities.list("sessions/sessions/42")
+        assert mock_request.call_count == 1
+
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_read_only(self, mock_request):
+        """Test read-only clients allow reads and block every mutating call."""
+        from jules_agent_sdk import ReadOnlyModeError
+
+        mock_response = Mock()
+        mock_response.ok = True
+        mock_response.status_code = 200
+        mock_response.json.return_value = {"name": "sessions/s1", "id": "s1"}
+        mock_request.return_value = mock_response
+
+        client = JulesClient(api_key="test-api-key", read_only=True)
+        client.sessions.get("s1")
+
+        for call in (
+            lambda: client.sessions.create(prompt="Fix bug", source="sources/repo1"),
+            lambda: client.sessions.send_message("s1", "hi"),
+            lambda: client.sessions.approve_plan("s1"),
+        ):
+            with pytest.raises(ReadOnlyModeError):
+                call()
+        assert mock_request.call_count == 1
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_session_handle(self, mock_request):
+        """Test session handles forward the bound session name."""
+        mock_request.return_value = {"name": "sessions/42", "id": "42", "state": "COMPLETED"}
+
+        client = JulesClient(api_key="test-api-key")
+        handle = client.session("https://jules.google.com/session/42")
+        assert handle.id == "42"
+
+        handle.send_message("Add tests")
+        mock_request.assert_called_with(
+            "POST", "sessions/42:sendMessage", params=None, json={"prompt": "Add tests"}
+        )
+        assert handle.wait(poll_interval=0).state == SessionState.COMPLETED
+        mock_request.assert_called_with("GET", "sessions/42", params=None)
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_source_handle(self, mock_request):
+        """Test sources resolve from owner/repo and handles scope sessions."""
+        repo = {
+            "name": "sources/github/octo/app",
+            "id": "github/octo/app",
+            "githubRepo": {"owner": "octo", "repo": "app", "branches": [{"displayName": "main"}]},
+        }
+
+        def respond(method, path, params=None, json=None):
+            if path == "sources":
+                return {"sources": [{"name": "sources/github/octo/other"}, repo]}
+            if path == "sources/github/octo/app":
+                return repo
+            if path == "sessions" and method == "GET":
+                return {
+                    "sessions": [
+                        {"id": "1", "sourceContext": {"source": "sources/github/octo/app"}},
+                        {"id": "2", "sourceContext": {"source": "sources/github/octo/other"}},
+                    ]
+                }
+            return {"id": "3", "sourceContext": json["sourceContext"]}
+
+        mock_request.side_effect = respond
+        client = JulesClient(api_key="test-api-key")
+
+        handle = client.source("octo/app")
+        assert handle.name == "sources/github/octo/app"
+        assert [s.id for s in handle.list_sessions()] == ["1"]
+        assert handle.list_branches() == ["main"]
+        assert handle.create_session("Fix").source_context.source == handle.name
+
+        with pytest.raises(JulesNotFoundError):
+            client.sources.resolve("octo/missing")
+
+        with pytest.raises(JulesNotFoundError) as exc_info:
+            client.sources.resolve("Octo/App")
+        assert exc_info.value.suggestions == ["octo/app"]
+        assert "did you mean 'octo/app'" in str(exc_info.value)
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_source_name_memoization(self, mock_request):
+        """Test owner/repo shorthands are resolved once and reused by every API."""
+        repo = {
+            "name": "sources/github/octo/app",
+            "githubRepo": {"owner": "octo", "repo": "app"},
+        }
+
+        def respond(method, path, params=None, json=None):
+            if path == "sources":
+                return {"sources": [repo]}
+            if path == "sources/github/octo/app":
+                return repo
+            return {"name": "sessions/1", "sourceContext": json["sourceContext"]}
+
+        mock_request.side_effect = respond
+        client = JulesClient(api_key="test-api-key")
+
+        assert client.sources.resolve_name("octo/app") == "sources/github/octo/app"
+        assert client.sources.resolve_name("github/octo/app") == "sources/github/octo/app"
+        session = client.sessions.create(prompt="Fix", source="octo/app")
+        assert session.source_context.source == "sources/github/octo/app"
+        assert client.sources.get("octo/app").name == "sources/github/octo/app"
+
+        list_calls = [c for c in mock_request.call_args_list if c[0][1] == "sources"]
+        assert len(list_calls) == 1
+
+        client.sources.clear_cache()
+        client.sources.resolve_name("octo/app")
+        list_calls = [c for c in mock_request.call_args_list if c[0][1] == "sources"]
+        assert len(list_calls) == 2
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_create_validate_branch(self, mock_request):
+        """Test a missing starting branch fails before the session is created."""
+        from jules_agent_sdk import BranchNotFoundError
+
+        mock_request.return_value = {
+            "name": "sources/repo1",
+            "githubRepo": {
+                "owner": "octo",
+                "repo": "app",
+                "branches": [{"displayName": "main"}, {"displayName": "develop"}],
+            },
+        }
+        client = JulesClient(api_key="test-api-key")
+
+        with pytest.raises(BranchNotFoundError) as exc_info:
+            client.sessions.create(
+                prompt="Fix bug",
+                source="sources/repo1",
+                starting_branch="mian",
+                validate_branch=True,
+            )
+        assert exc_info.value.suggestions == ["main"]
+        assert "did you mean 'main'" in str(exc_info.value)
+        mock_request.assert_called_once_with("GET", "sources/repo1", params=None)
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_create_pin_default_branch(self, mock_request):
+        """Test the source's default branch is sent when no starting branch is given."""
+        source = {
+            "name": "sources/repo1",
+            "githubRepo": {
+                "owner": "octo",
+                "repo": "app",
+                "defaultBranch": {"displayName": "trunk"},
+            },
+        }
+
+        def respond(method, path, **kwargs):
+            if method == "GET":
+                return source
+            return {"name": "sessions/s1", "sourceContext": kwargs["json"]["sourceContext"]}
+
+        mock_request.side_effect = respond
+        client = JulesClient(api_key="test-api-key", pin_default_branch=True)
+
+        session = client.sessions.create(prompt="Fix bug", source="sources/repo1")
+        assert session.starting_branch == "trunk"
+
+        session = client.sessions.create(
+            prompt="Fix bug", source="sources/repo1", starting_branch="develop"
+        )
+        assert session.starting_branch == "develop"
+
+        session = client.sessions.create(
+            prompt="Fix bug", source="sources/repo1", pin_default_branch=False
+        )
+        assert session.starting_branch == ""
+        assert [c[0][0] for c in mock_request.call_args_list] == ["GET", "POST", "POST", "POST"]
+
+    @patch("jules_agent_sdk.base.time.sleep")
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_create_idempotency_key(self, mock_request, mock_sleep):
+        """Test a retried create resends the same idempotency key."""
+        from requests.exceptions import ConnectionError
+
+        created = {"name": "sessions/s1", "sourceContext": {"source": "sources/repo1"}}
+        mock_request.side_effect = [
+            ConnectionError("connection reset"),
+            response(200, created),
+        ]
+        client = JulesClient(api_key="test-api-key")
+
+        session = client.sessions.create(prompt="Fix bug", source="sources/repo1")
+        keys = [c.kwargs["headers"]["Idempotency-Key"] for c in mock_request.call_args_list]
+        assert len(keys) == 2 and keys[0] == keys[1]
+        assert session.idempotency_key == keys[0]
+        assert session == Session.from_dict(created)
+
+        mock_request.side_effect = [response(200, created)]
+        session = client.sessions.create(
+            prompt="Fix bug", source="sources/repo1", idempotency_key="job-42"
+        )
+        assert mock_request.call_args.kwargs["headers"]["Idempotency-Key"] == "job-42"
+        assert session.idempotency_key == "job-42"
+
+        mock_request.side_effect = [response(200, created)]
+        client = JulesClient(api_key="test-api-key", idempotency_key_header=None)
+        session = client.sessions.create(prompt="Fix bug", source="sources/repo1")
+        assert session.idempotency_key == ""
+        assert "Idempotency-Key" not in mock_request.call_args.kwargs["headers"]
+
+        with pytest.raises(ValueError, match="idempotency_key_header"):
+            client.sessions.create(
+                prompt="Fix bug", source="sources/repo1", idempotency_key="job-42"
+            )
+        assert mock_request.call_count == 4
+
     @patch("jules_agent_sdk.base.BaseClient._request")
     def test_sources_list(self, mock_request):
         """Test listing sources."""
@@ -135,6 +962,52 @@ class TestJulesClient:
         assert result["sources"][0].id == "src1"
         assert result["sources"][0].github_repo.owner == "test"
 
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_sources_list_source_filter(self, mock_request):
+        """Test a SourceFilter sends its name expression and filters the rest locally."""
+        from jules_agent_sdk import SourceFilter
+
+        mock_request.return_value = {
+            "sources": [
+                {"name": "sources/github/octo/a", "githubRepo": {"owner": "octo", "repo": "a"}},
+                {
+                    "name": "sources/github/octo/b",
+                    "githubRepo": {"owner": "octo", "repo": "b", "isPrivate": True},
+                },
+            ]
+        }
+        client = JulesClient(api_key="test-api-key")
+
+        f = SourceFilter.named("octo/a", "octo/b") & SourceFilter.private()
+        result = client.sources.list(filter_str=f)
+
+        assert [s.name for s in result["sources"]] == ["sources/github/octo/b"]
+        assert mock_request.call_args[1]["params"]["filter"] == (
+            "name=sources/github/octo/a OR name=sources/github/octo/b"
+        )
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_sources_iter_filter(self, mock_request):
+        """Test iter_filter applies the predicate and fetches pages lazily."""
+
+        def source(owner, repo):
+            github_repo = {"owner": owner, "repo": repo}
+            return {"name": f"sources/github/{owner}/{repo}", "githubRepo": github_repo}
+
+        mock_request.side_effect = [
+            {"sources": [source("other", "a"), source("octo", "b")], "nextPageToken": "p2"},
+            {"sources": [source("octo", "c")], "nextPageToken": "p3"},
+            {"sources": [source("octo", "d")]},
+        ]
+
+        client = JulesClient(api_key="test-api-key")
+        matches = client.sources.iter_filter(lambda s: s.github_repo.owner == "octo")
+
+        assert next(matches).github_repo.repo == "b"
+        assert mock_request.call_count == 1
+        assert [s.github_repo.repo for s in matches] == ["c", "d"]
+        assert mock_request.call_count == 3
+
 
 class TestErrorHandling:
     """Test error handling."""
@@ -153,6 +1026,29 @@ class TestErrorHandling:
         with pytest.raises(JulesAuthenticationError):
             client.sessions.list()
 
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_error_carries_request_context(self, mock_request):
+        """Test API errors record operation, resource and attempts."""
+        mock_response = Mock()
+        mock_response.ok = False
+        mock_response.status_code = 404
+        mock_response.json.return_value = {"error": {"message": "Session not found"}}
+        mock_request.return_value = mock_response
+
+        client = JulesClient(api_key="test-key")
+
+        with pytest.raises(JulesNotFoundError) as exc_info:
+            client.sessions.approve_plan("abc")
+
+        error = exc_info.value
+        assert error.operation == "POST sessions/abc:approvePlan"
+        assert error.resource == "sessions/abc"
+        assert error.attempts == 1
+        assert str(error) == (
+            "Session not found (operation=POST sessions/abc:approvePlan, "
+            "resource=sessions/abc, attempts=1)"
+        )
+
     @patch("jules_agent_sdk.base.requests.Session.request")
     def test_validation_error(self, mock_request):
         """Test validation error handling."""
@@ -166,3 +1062,476 @@ class TestErrorHandling:
 
         with pytest.raises(JulesValidationError):
             client.sessions.create(prompt="", source="")
+
+    @patch("jules_agent_sdk.base.time.sleep")
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_html_error_response(self, mock_request, mock_sleep):
+        """Test an HTML gateway page raises UnexpectedContentTypeError after retries."""
+        mock_response = Mock()
+        mock_response.ok = False
+        mock_response.status_code = 502
+        mock_response.headers = {"Content-Type": "text/html"}
+        mock_response.text = "<html>\n  <body>Bad Gateway</body>\n</html>"
+        mock_response.json.side_effect = ValueError("Expecting value")
+        mock_request.return_value = mock_response
+
+        client = JulesClient(api_key="test-key", max_retries=2)
+
+        with pytest.raises(UnexpectedContentTypeError) as exc_info:
+            client.sessions.list()
+
+        assert exc_info.value.status_code == 502
+        assert exc_info.value.content_type == "text/html"
+        assert exc_info.value.body_snippet == "<html> <body>Bad Gateway</body> </html>"
+        assert mock_request.call_count == 2
+        assert exc_info.value.attempts == 2
+
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_html_client_error_response(self, mock_request):
+        """Test non-JSON 4xx bodies still raise the status code's exception class."""
+        mock_response = Mock()
+        mock_response.ok = False
+        mock_response.headers = {"Content-Type": "text/html"}
+        mock_response.text = "<html><body>Not Found</body></html>"
+        mock_response.json.side_effect = ValueError("Expecting value")
+        mock_request.return_value = mock_response
+        client = JulesClient(api_key="test-key")
+
+        for status, error_class in (
+            (404, JulesNotFoundError),
+            (401, JulesAuthenticationError),
+            (400, JulesValidationError),
+        ):
+            mock_response.status_code = status
+            with pytest.raises(error_class) as exc_info:
+                client.sessions.get("s1")
+            assert isinstance(exc_info.value, UnexpectedContentTypeError)
+            assert exc_info.value.status_code == status
+            assert not is_retryable(exc_info.value)
+
+    def test_parse_retry_after(self):
+        """Test Retry-After accepts delay-seconds and HTTP dates."""
+        from email.utils import format_datetime
+
+        from jules_agent_sdk.base import parse_retry_after
+
+        assert parse_retry_after("120") == 120.0
+        assert parse_retry_after(" 1.5 ") == 1.5
+        assert parse_retry_after("-3") == 0.0
+        assert parse_retry_after(None) is None
+        assert parse_retry_after("soon") is None
+
+        future = datetime.now(timezone.utc) + timedelta(seconds=30)
+        assert 25 < parse_retry_after(format_datetime(future, usegmt=True)) <= 30
+        assert parse_retry_after("Wed, 21 Oct 2015 07:28:00 GMT") == 0.0
+
+    @patch("jules_agent_sdk.base.time.sleep")
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_rate_limit_retry_after(self, mock_request, mock_sleep):
+        """Test a 429 is retried after the Retry-After delay, up to max_retry_after."""
+        session = {"name": "sessions/s1"}
+        mock_request.side_effect = [
+            response(429, session, {"Retry-After": "7"}),
+            response(200, session),
+        ]
+        client = JulesClient(api_key="test-key")
+
+        assert client.sessions.get("s1").name == "sessions/s1"
+        mock_sleep.assert_called_once_with(7.0)
+
+        mock_request.side_effect = [response(429, session, {"Retry-After": "120"})]
+        with pytest.raises(JulesRateLimitError) as exc_info:
+            client.sessions.get("s1")
+        assert exc_info.value.response == {"retry_after_seconds": 120.0}
+        assert "Retry after 120 seconds" in str(exc_info.value)
+
+        mock_request.side_effect = [response(429, session, {"Retry-After": "1"})]
+        no_retry = JulesClient(api_key="test-key", max_retry_after=0)
+        with pytest.raises(JulesRateLimitError):
+            no_retry.sessions.get("s1")
+        assert mock_request.call_count == 4
+
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_html_success_response(self, mock_request):
+        """Test a captive portal 200 HTML page raises UnexpectedContentTypeError."""
+        mock_response = Mock()
+        mock_response.ok = True
+        mock_response.status_code = 200
+        mock_response.content = b"<html>Sign in</html>"
+        mock_response.headers = {"Content-Type": "text/html; charset=utf-8"}
+        mock_response.text = "<html>Sign in</html>"
+        mock_response.json.side_effect = ValueError("Expecting value")
+        mock_request.return_value = mock_response
+
+        client = JulesClient(api_key="test-key")
+
+        with pytest.raises(UnexpectedContentTypeError, match="text/html"):
+            client.sessions.get("s1")
+
+
+class TestRequestContext:
+    """Test per-call request context."""
+
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_api_key_override(self, mock_request):
+        """Test use_api_key overrides the key only inside the block."""
+        from jules_agent_sdk import use_api_key
+
+        mock_response = Mock()
+        mock_response.ok = True
+        mock_response.status_code = 204
+        mock_request.return_value = mock_response
+
+        client = JulesClient(api_key="default-key")
+
+        with use_api_key("tenant-key"):
+            client.sessions.approve_plan("s1")
+        assert mock_request.call_args.kwargs["headers"]["X-Goog-Api-Key"] == "tenant-key"
+
+        client.sessions.approve_plan("s1")
+        assert "X-Goog-Api-Key" not in mock_request.call_args.kwargs["headers"]
+        assert client._base_client.session.headers["X-Goog-Api-Key"] == "default-key"
+
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_extra_headers(self, mock_request):
+        """Test client-level and nested per-call headers."""
+        from jules_agent_sdk import use_headers
+
+        mock_response = Mock()
+        mock_response.ok = True
+        mock_response.status_code = 204
+        mock_request.return_value = mock_response
+
+        client = JulesClient(api_key="key", extra_headers={"X-Gateway-Token": "gw"})
+        assert client._base_client.session.headers["X-Gateway-Token"] == "gw"
+
+        with use_headers({"X-Cost-Center": "infra", "X-Team": "a"}):
+            with use_headers({"X-Team": "b"}):
+                client.sessions.approve_plan("s1")
+            assert mock_request.call_args.kwargs["headers"] == {
+                "X-Cost-Center": "infra",
+                "X-Team": "b",
+            }
+
+        client.sessions.approve_plan("s1")
+        assert mock_request.call_args.kwargs["headers"] == {}
+
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_request_id(self, mock_request):
+        """Test the server's request ID is attached to errors."""
+        mock_response = Mock()
+        mock_response.ok = False
+        mock_response.status_code = 400
+        mock_response.headers = {"X-Request-Id": "srv-77"}
+        mock_response.json.return_value = {"error": {"message": "Invalid source"}}
+        mock_request.return_value = mock_response
+
+        client = JulesClient(api_key="key")
+        with pytest.raises(JulesValidationError) as exc_info:
+            client.sessions.create(prompt="Fix bug", source="sources/repo1")
+        assert exc_info.value.request_id == "srv-77"
+        assert "request_id=srv-77" in str(exc_info.value)
+
+        mock_response.headers = {}
+        with pytest.raises(JulesValidationError) as exc_info:
+            client.sessions.create(prompt="Fix bug", source="sources/repo1")
+        assert exc_info.value.request_id is None
+
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_correlation_id(self, mock_request):
+        """Test correlation IDs are sent and attached to errors."""
+        from jules_agent_sdk import use_correlation_id
+
+        mock_response = Mock()
+        mock_response.ok = False
+        mock_response.status_code = 404
+        mock_response.json.return_value = {"error": {"message": "Not found"}}
+        mock_request.return_value = mock_response
+
+        client = JulesClient(api_key="key")
+        with use_correlation_id("req-123"):
+            with pytest.raises(JulesNotFoundError) as exc_info:
+                client.sessions.get("abc")
+        assert mock_request.call_args.kwargs["headers"] == {"X-Correlation-ID": "req-123"}
+        assert exc_info.value.correlation_id == "req-123"
+        assert "correlation_id=req-123" in str(exc_info.value)
+
+        client = JulesClient(
+            api_key="key",
+            correlation_id_extractor=lambda: "trace-9",
+            correlation_id_header="X-Trace-ID",
+        )
+        with pytest.raises(JulesNotFoundError):
+            client.sessions.get("abc")
+        assert mock_request.call_args.kwargs["headers"] == {"X-Trace-ID": "trace-9"}
+
+
+class TestFailover:
+    """Test endpoint failover."""
+
+    @patch("jules_agent_sdk.base.time.sleep")
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_failover_on_connection_errors(self, mock_request, mock_sleep):
+        """Test client moves to the fallback endpoint after exhausting retries."""
+        from requests.exceptions import ConnectionError
+
+        ok_response = Mock()
+        ok_response.ok = True
+        ok_response.status_code = 200
+        ok_response.content = b"{}"
+        ok_response.json.return_value = {"sessions": []}
+
+        def respond(method, url, **kwargs):
+            if url.startswith("https://primary"):
+                raise ConnectionError("connection refused")
+            return ok_response
+
+        mock_request.side_effect = respond
+        events = []
+
+        client = JulesClient(
+            api_key="test-key",
+            base_url="https://primary/v1alpha",
+            fallback_base_urls=["https://secondary/v1alpha"],
+            on_failover=lambda old, new, err: events.append((old, new)),
+        )
+        result = client.sessions.list()
+
+        assert result["sessions"] == []
+        assert events == [("https://primary/v1alpha", "https://secondary/v1alpha")]
+        assert client._base_client.base_url == "https://secondary/v1alpha"
+        assert client._base_client.get_stats()["failovers"] == 1
+
+    @patch("jules_agent_sdk.base.time.sleep")
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_failing_failover_callback_is_isolated(self, mock_request, mock_sleep):
+        """Test a raising on_failover callback does not break the request."""
+        from requests.exceptions import ConnectionError
+
+        ok_response = Mock()
+        ok_response.ok = True
+        ok_response.status_code = 204
+
+        def respond(method, url, **kwargs):
+            if url.startswith("https://primary"):
+                raise ConnectionError("connection refused")
+            return ok_response
+
+        def broken_notifier(old, new, err):
+            raise RuntimeError("Slack is down")
+
+        mock_request.side_effect = respond
+        client = JulesClient(
+            api_key="test-key",
+            base_url="https://primary/v1alpha",
+            fallback_base_urls=["https://secondary/v1alpha"],
+            on_failover=broken_notifier,
+        )
+
+        client.sessions.approve_plan("s1")
+        assert client._base_client.get_stats()["callback_errors"] == 1
+
+        strict = JulesClient(
+            api_key="test-key",
+            base_url="https://primary/v1alpha",
+            fallback_base_urls=["https://secondary/v1alpha"],
+            on_failover=broken_notifier,
+            raise_callback_errors=True,
+        )
+        with pytest.raises(RuntimeError, match="Slack is down"):
+            strict.sessions.approve_plan("s1")
+
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_no_failover_on_client_errors(self, mock_request):
+        """Test 4xx errors are raised without failing over."""
+        mock_response = Mock()
+        mock_response.ok = False
+        mock_response.status_code = 400
+        mock_response.json.return_value = {"error": {"message": "Invalid request"}}
+        mock_request.return_value = mock_response
+
+        client = JulesClient(
+            api_key="test-key",
+            base_url="https://primary/v1alpha",
+            fallback_base_urls=["https://secondary/v1alpha"],
+        )
+
+        with pytest.raises(JulesValidationError):
+            client.sessions.list()
+        assert client._base_client.base_url == "https://primary/v1alpha"
+
+
+class TestTransport:
+    """Test transport customization."""
+
+    def test_socket_options_applied_to_pool(self):
+        """Test socket options reach the urllib3 pool manager."""
+        from jules_agent_sdk.base import TCP_KEEPALIVE_SOCKET_OPTIONS
+
+        client = JulesClient(api_key="test-key", socket_options=TCP_KEEPALIVE_SOCKET_OPTIONS)
+        adapter = client._base_client.session.get_adapter("https://jules.googleapis.com")
+
+        pool_kw = adapter.poolmanager.connection_pool_kw
+        assert pool_kw["socket_options"] == TCP_KEEPALIVE_SOCKET_OPTIONS
+
+    def test_pool_size_options(self):
+        """Test pool size options configure the default adapter."""
+        client = JulesClient(api_key="test-key", pool_maxsize=200, pool_block=True)
+        adapter = client._base_client.session.get_adapter("https://jules.googleapis.com")
+
+        assert adapter.poolmanager.connection_pool_kw["maxsize"] == 200
+        assert adapter.poolmanager.connection_pool_kw["block"] is True
+
+    def test_connection_pool_stats(self):
+        """Test get_stats reports connections opened and reused by the pools."""
+        client = JulesClient(api_key="test-key")
+        adapter = client._base_client.session.get_adapter("https://jules.googleapis.com")
+        pool = adapter.poolmanager.connection_from_url("https://jules.googleapis.com")
+        pool.num_connections, pool.num_requests = 2, 7
+
+        stats = client._base_client.get_stats()
+        assert stats["connections_opened"] == 2
+        assert stats["connections_reused"] == 5
+
+    @patch("jules_agent_sdk.base.time.sleep")
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_error_responses_are_drained(self, mock_request, mock_sleep):
+        """Test retried and final error responses are read and closed."""
+        responses = []
+
+        def respond(**kwargs):
+            response = Mock()
+            response.ok = False
+            response.status_code = 503
+            response.headers = {}
+            response.json.return_value = {"error": {"message": "unavailable"}}
+            responses.append(response)
+            return response
+
+        mock_request.side_effect = respond
+        client = JulesClient(api_key="test-key", max_retries=2)
+
+        with pytest.raises(JulesAPIError):
+            client.sessions.get("s1")
+
+        assert len(responses) == 2
+        assert all(r.close.called for r in responses)
+
+    def test_classify_transport_error(self):
+        """Test network failures are classified from their root cause."""
+        import socket
+
+        from requests.exceptions import ConnectionError, ReadTimeout
+
+        from jules_agent_sdk.base import classify_transport_error
+
+        def wrapped(cause):
+            try:
+                raise cause
+            except OSError as e:
+                return ConnectionError(e)
+
+        assert classify_transport_error(ReadTimeout("read timed out")) == "timeout"
+        assert classify_transport_error(wrapped(socket.gaierror(-2, "unknown"))) == "dns"
+        assert classify_transport_error(wrapped(ConnectionResetError(104, "reset"))) == (
+            "connection_reset"
+        )
+        assert classify_transport_error(ConnectionError("refused")) == "connection"
+
+    @patch("jules_agent_sdk.base.time.sleep")
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_transport_error_categories(self, mock_request, mock_sleep):
+        """Test per-category error counts and category-specific retries."""
+        from requests.exceptions import ConnectTimeout
+
+        mock_request.side_effect = ConnectTimeout("timed out")
+        client = JulesClient(
+            api_key="test-key", max_retries=3, retry_transport_errors=["connection_reset"]
+        )
+
+        with pytest.raises(JulesAPIError):
+            client.sessions.get("s1")
+
+        assert mock_request.call_count == 1
+        stats = client._base_client.get_stats()
+        assert stats["transport_timeout"] == 1
+        assert stats["transport_dns"] == 0
+        assert stats["api_errors"] == 0
+        assert stats["errors"] == 1
+
+    def test_stats_snapshot_written_on_close(self):
+        """Test close() appends a JSON stats snapshot to stats_path."""
+        import json
+        import os
+        import tempfile
+
+        with tempfile.TemporaryDirectory() as tmp:
+            path = os.path.join(tmp, "stats.jsonl")
+            for _ in range(2):
+                client = JulesClient(api_key="test-key", stats_path=path)
+                client._base_client.request_count = 3
+                client.close()
+
+            with open(path, encoding="utf-8") as fp:
+                snapshots = [json.loads(line) for line in fp]
+
+        assert len(snapshots) == 2
+        assert snapshots[0]["requests"] == 3
+        assert snapshots[0]["base_url"] == "https://jules.googleapis.com/v1alpha"
+        assert "time" in snapshots[0]
+
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_middleware_chain(self, mock_request):
+        """Test middlewares wrap each attempt in order and can mutate requests."""
+        calls = []
+
+        def tag(name):
+            def middleware(next_doer):
+                def doer(method, url, **kwargs):
+                    calls.append(f"{name}>")
+                    kwargs["headers"] = {**(kwargs.get("headers") or {}), f"X-{name}": "1"}
+                    response = next_doer(method=method, url=url, **kwargs)
+                    calls.append(f"<{name}")
+                    return response
+
+                return doer
+
+            return middleware
+
+        response = Mock()
+        response.ok = True
+        response.status_code = 200
+        response.content = b"{}"
+        response.json.return_value = {"name": "sessions/s1", "sourceContext": {}}
+        mock_request.return_value = response
+
+        client = JulesClient(api_key="test-key", middlewares=[tag("outer")])
+        client.use(tag("inner"))
+        client.sessions.get("s1")
+
+        assert calls == ["outer>", "inner>", "<inner", "<outer"]
+        headers = mock_request.call_args.kwargs["headers"]
+        assert headers["X-outer"] == "1" and headers["X-inner"] == "1"
+
+    def test_custom_transport_adapter(self):
+        """Test a custom adapter is mounted for both schemes."""
+        from requests.adapters import HTTPAdapter
+
+        adapter = HTTPAdapter()
+        client = JulesClient(api_key="test-key", transport_adapter=adapter)
+
+        assert client._base_client.session.get_adapter("https://example.com") is adapter
+        assert client._base_client.session.get_adapter("http://example.com") is adapter
+
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_connect_timeout(self, mock_request):
+        """Test connect timeout is sent as a (connect, read) tuple."""
+        mock_response = Mock()
+        mock_response.ok = True
+        mock_response.status_code = 204
+        mock_request.return_value = mock_response
+
+        client = JulesClient(api_key="test-key", timeout=30, connect_timeout=5)
+        client.sessions.approve_plan("s1")
+
+        assert mock_request.call_args.kwargs["timeout"] == (5, 30)
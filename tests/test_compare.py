@@ -0,0 +1,107 @@
+"""Tests for session comparison."""
+
+from unittest.mock import patch
+from jules_agent_sdk import JulesClient
+from jules_agent_sdk.compare import compare_sessions
+
+PATCH = """diff --git a/app.py b/app.py
+--- a/app.py
++++ b/app.py
+@@ -1,2 +1,3 @@
+ import os
+-x = 1
++x = 2
++y = 3
+"""
+
+DELETIONS = "".join(
+    f"""diff --git a/{name} b/{name}
+deleted file mode 100644
+--- a/{name}
++++ /dev/null
+@@ -1 +0,0 @@
+-print("{name}")
+"""
+    for name in ("x.py", "y.py", "z.py")
+)
+
+SESSIONS = {
+    "sessions/a": {
+        "name": "sessions/a",
+        "prompt": "Fix the bug",
+        "state": "COMPLETED",
+        "createTime": "2024-05-01T12:00:00.123456789Z",
+        "updateTime": "2024-05-01T12:10:00.123456789Z",
+        "outputs": [{"pullRequest": {"url": "https://github.com/o/r/pull/1"}}],
+    },
+    "sessions/b": {"name": "sessions/b", "prompt": "Fix the bug ", "state": "IN_PROGRESS"},
+}
+
+ACTIVITIES = {
+    "sessions/a/activities": [
+        {"name": "a1", "planGenerated": {"plan": {"id": "p", "steps": [{"title": "Fix"}]}}},
+        {
+            "name": "a2",
+            "sessionCompleted": {},
+            "artifacts": [{"changeSet": {"gitPatch": {"unidiffPatch": PATCH}}}],
+        },
+    ],
+    "sessions/b/activities": [{"name": "b1"}],
+}
+
+
+class TestCompareSessions:
+    """Test cases for compare_sessions."""
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_compare_sessions(self, mock_request):
+        """Test plans, diff stats, durations and outcomes are compared."""
+
+        def respond(method, path, params=None, json=None):
+            if path in SESSIONS:
+                return {"sourceContext": {}, **SESSIONS[path]}
+            return {"activities": ACTIVITIES[path]}
+
+        mock_request.side_effect = respond
+        client = JulesClient(api_key="test-api-key")
+
+        comparison = compare_sessions(client, "a", "b")
+
+        assert comparison.same_prompt
+        assert comparison.a.plan_steps == 1
+        assert (comparison.a.files_changed, comparison.a.lines_added) == (1, 2)
+        assert comparison.a.lines_removed == 1
+        assert comparison.a.duration == 600.0
+        assert comparison.b.duration is None
+        assert comparison.b.files_changed == 0
+
+        rows = dict((name, (a, b)) for name, a, b in comparison.rows())
+        assert rows["state"] == ("COMPLETED", "IN_PROGRESS")
+        assert rows["pull request"] == ("https://github.com/o/r/pull/1", "")
+        assert str(comparison).splitlines()[0].split() == ["sessions/a", "sessions/b"]
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_compare_sessions_deleted_files(self, mock_request):
+        """Test every deleted file counts as a changed file."""
+        activities = [
+            {
+                "name": "b2",
+                "sessionCompleted": {},
+                "artifacts": [{"changeSet": {"gitPatch": {"unidiffPatch": DELETIONS}}}],
+            }
+        ]
+
+        def respond(method, path, params=None, json=None):
+            if path in SESSIONS:
+                return {"sourceContext": {}, **SESSIONS[path]}
+            if path == "sessions/b/activities":
+                return {"activities": activities}
+            return {"activities": ACTIVITIES[path]}
+
+        mock_request.side_effect = respond
+        client = JulesClient(api_key="test-api-key")
+
+        comparison = compare_sessions(client, "a", "b")
+
+        assert comparison.b.files_changed == 3
+        assert comparison.b.lines_removed == 3
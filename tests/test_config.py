@@ -0,0 +1,37 @@
+"""Tests for ClientConfig."""
+
+from jules_agent_sdk.config import ClientConfig, mask_secret
+
+
+class TestClientConfig:
+    """Test cases for ClientConfig."""
+
+    def test_mask_secret(self):
+        """Test long secrets keep their last 4 characters and short ones none."""
+        assert mask_secret("AIzaSyExampleKey1234") == "****1234"
+        assert mask_secret("short") == "REDACTED"
+
+    def test_redacted(self):
+        """Test the redacted copy masks the key and credential headers only."""
+        config = ClientConfig(
+            api_key="AIzaSyExampleKey1234",
+            extra_headers={"Authorization": "Bearer token", "X-Team": "core"},
+        )
+        redacted = config.redacted()
+
+        assert redacted.api_key == "****1234"
+        assert redacted.extra_headers == {"Authorization": "REDACTED", "X-Team": "core"}
+        assert redacted.timeout == config.timeout
+        assert config.api_key == "AIzaSyExampleKey1234"
+
+    def test_repr_never_leaks_secrets(self):
+        """Test printing a config shows settings but not secrets."""
+        config = ClientConfig(
+            api_key="AIzaSyExampleKey1234", extra_headers={"X-Goog-Api-Key": "other-secret"}
+        )
+
+        for text in (repr(config), str(config), f"{config}"):
+            assert "AIzaSyExampleKey1234" not in text
+            assert "other-secret" not in text
+            assert "api_key='****1234'" in text
+            assert "timeout=30" in text
@@ -0,0 +1,98 @@
+"""Tests for configurable response decoding."""
+
+import pytest
+from decimal import Decimal
+from unittest.mock import Mock, patch
+from jules_agent_sdk import DecodeError, DecodeOptions, JulesClient
+from jules_agent_sdk.decoding import decode, problems
+from jules_agent_sdk.models import Activity, ProgressUpdate, Session, Source
+
+SESSION = {
+    "name": "sessions/1",
+    "id": "1",
+    "sourceContext": {"source": "sources/repo1", "githubRepoContext": {"startingBranch": "main"}},
+    "createTime": "2024-05-01T12:00:00.123Z",
+    "outputs": [{"pullRequest": {"url": "https://github.com/o/r/pull/1"}}],
+}
+
+
+class TestDecoding:
+    """Test cases for DecodeOptions."""
+
+    def test_lenient_by_default(self):
+        """Test unknown fields and odd timestamps pass without options."""
+        data = {**SESSION, "createTime": "yesterday", "newField": 1}
+        assert decode(Session, data, DecodeOptions()).create_time == "yesterday"
+
+    def test_reject_unknown_fields(self):
+        """Test unknown fields are reported with their path, including nested ones."""
+        options = DecodeOptions(reject_unknown_fields=True)
+        assert decode(Session, SESSION, options).id == "1"
+
+        data = {**SESSION, "outputs": [{"pullRequest": {"url": "u", "draft": True}}]}
+        with pytest.raises(DecodeError) as exc_info:
+            decode(Session, data, options)
+        assert exc_info.value.path == "Session.outputs[0].pullRequest.draft"
+
+    def test_strict_timestamps(self):
+        """Test timestamps must be RFC 3339 in strict mode."""
+        options = DecodeOptions(strict_timestamps=True)
+        decode(Session, SESSION, options)
+
+        with pytest.raises(DecodeError, match="RFC 3339"):
+            decode(Session, {**SESSION, "createTime": "2024-05-01 12:00"}, options)
+
+    def test_strict_types(self):
+        """Test type mismatches are reported with their path in strict mode."""
+        options = DecodeOptions(strict_types=True)
+        decode(Session, SESSION, options)
+
+        data = {**SESSION, "sourceContext": {"source": 42}}
+        with pytest.raises(DecodeError) as exc_info:
+            decode(Session, data, options)
+        assert exc_info.value.path == "Session.sourceContext.source"
+
+        with pytest.raises(DecodeError, match="unexpected list"):
+            decode(Session, {**SESSION, "title": ["a"]}, options)
+
+    def test_strict_types_optional_fields(self):
+        """Test Optional fields accept null but not values of another type."""
+        options = DecodeOptions(strict_types=True)
+        decode(ProgressUpdate, {"stepIndex": None}, options)
+        decode(Source, {"name": "sources/1", "githubRepo": None}, options)
+
+        with pytest.raises(DecodeError) as exc_info:
+            decode(ProgressUpdate, {"stepIndex": "two"}, options)
+        assert exc_info.value.path == "ProgressUpdate.stepIndex"
+
+        assert [p[1] for p in problems(Source, {"githubRepo": 5})] == ["Source.githubRepo"]
+        with pytest.raises(DecodeError, match="unexpected str"):
+            decode(Activity, {"name": "a", "agentMessaged": "hello"}, options)
+
+    @patch("jules_agent_sdk.decoding.logger")
+    def test_log_mismatches_in_lenient_mode(self, mock_logger):
+        """Test lenient decoding logs each drifting field once instead of failing."""
+        options = DecodeOptions(log_mismatches=True)
+        data = {**SESSION, "title": 7, "newFieldForLogTest": True}
+
+        for _ in range(2):
+            assert decode(Session, data, options).title == 7
+
+        messages = [c.args[0] for c in mock_logger.warning.call_args_list]
+        assert len(messages) == 2
+        assert any("Session.title: unexpected int" in m for m in messages)
+        assert any("Session.newFieldForLogTest: unknown field" in m for m in messages)
+
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_use_decimal(self, mock_request):
+        """Test the client asks for Decimal floats when configured."""
+        mock_response = Mock()
+        mock_response.ok = True
+        mock_response.status_code = 200
+        mock_response.json.return_value = SESSION
+        mock_request.return_value = mock_response
+
+        client = JulesClient(api_key="key", decode_options=DecodeOptions(use_decimal=True))
+        client.sessions.get("1")
+
+        mock_response.json.assert_called_once_with(parse_float=Decimal)
@@ -0,0 +1,60 @@
+"""Tests for API endpoint deprecation notices."""
+
+from datetime import datetime, timezone
+from unittest.mock import Mock, patch
+
+from requests.structures import CaseInsensitiveDict
+
+from jules_agent_sdk.client import JulesClient
+from jules_agent_sdk.deprecation import parse_deprecation_headers
+
+
+class TestDeprecationHeaders:
+    """Test cases for Deprecation and Sunset response headers."""
+
+    def test_parse_headers(self):
+        """Test RFC 9745 and legacy deprecation dates, sunset dates and links."""
+        notice = parse_deprecation_headers(
+            "GET /v1alpha/sessions/{id}",
+            CaseInsensitiveDict(
+                {
+                    "deprecation": "@1767225600",
+                    "Sunset": "Wed, 01 Jul 2026 00:00:00 GMT",
+                    "Link": '<https://example.com/a>; rel="alternate", '
+                    '<https://example.com/migrate>; rel="deprecation"',
+                }
+            ),
+        )
+        assert notice.deprecated_at == datetime(2026, 1, 1, tzinfo=timezone.utc)
+        assert notice.sunset_at == datetime(2026, 7, 1, tzinfo=timezone.utc)
+        assert notice.link == "https://example.com/migrate"
+        assert str(notice) == (
+            "GET /v1alpha/sessions/{id} is deprecated and will be removed on 2026-07-01; "
+            "see https://example.com/migrate"
+        )
+
+        legacy = parse_deprecation_headers("GET /x", {"Deprecation": "true"})
+        assert legacy.deprecated_at is None and legacy.sunset_at is None
+        assert parse_deprecation_headers("GET /x", {"Deprecation": "false"}) is None
+        assert parse_deprecation_headers("GET /x", {"Content-Type": "a/b"}) is None
+
+    @patch("jules_agent_sdk.deprecation.logger")
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_client_records_notices_once_per_endpoint(self, mock_request, mock_logger):
+        """Test notices are collected and logged once per templated endpoint."""
+        response = Mock()
+        response.ok = True
+        response.status_code = 200
+        response.headers = CaseInsensitiveDict({"Deprecation": "true"})
+        response.content = b"{}"
+        response.json.return_value = {"name": "sessions/s1"}
+        mock_request.return_value = response
+
+        client = JulesClient(api_key="test-key")
+        client.sessions.get("s1")
+        client.sessions.get("s2")
+        client.sources.list()
+
+        endpoints = [n.endpoint for n in client.deprecation_notices()]
+        assert endpoints == ["GET /v1alpha/sessions/{id}", "GET /v1alpha/sources"]
+        assert mock_logger.warning.call_count == 2
@@ -0,0 +1,71 @@
+"""Tests for the unified diff parser."""
+
+import io
+from jules_agent_sdk.diff import iter_hunks
+from jules_agent_sdk.models import GitPatch
+
+PATCH = """diff --git a/app/auth.py b/app/auth.py
+index 1111111..2222222 100644
+--- a/app/auth.py
++++ b/app/auth.py
+@@ -10,3 +10,4 @@ def login(user):
+     token = issue(user)
+--- removed_looks_like_header
++    audit(user)
++    log(user)
+     return token
+@@ -40 +41 @@ class Session:
+-    ttl = 60
++    ttl = 3600
+\\ No newline at end of file
+diff --git a/README.md b/README.md
+new file mode 100644
+--- /dev/null
++++ b/README.md
+@@ -0,0 +1,2 @@
++# App
++Docs
+"""
+
+
+class TestDiff:
+    """Test cases for iter_hunks."""
+
+    def test_iter_hunks(self):
+        """Test hunks are parsed with paths, ranges and line counts."""
+        hunks = list(iter_hunks(PATCH))
+
+        assert len(hunks) == 3
+        first, second, third = hunks
+
+        assert first.old_path == first.new_path == "app/auth.py"
+        assert (first.old_start, first.old_count, first.new_start, first.new_count) == (
+            10,
+            3,
+            10,
+            4,
+        )
+        assert first.section == "def login(user):"
+        assert first.removed == 1
+        assert first.added == 2
+
+        assert (second.old_count, second.new_count) == (1, 1)
+        assert second.lines[-1] == "\\ No newline at end of file"
+
+        assert third.old_path == "/dev/null"
+        assert third.new_path == "README.md"
+        assert third.added == 2
+
+    def test_iter_hunks_streams_lines(self):
+        """Test a line iterator is consumed lazily."""
+        stream = io.StringIO(PATCH)
+        hunks = iter_hunks(stream)
+
+        first = next(hunks)
+        assert first.new_start == 10
+        assert stream.tell() < len(PATCH)
+
+    def test_git_patch_hunks(self):
+        """Test GitPatch exposes its hunks."""
+        patch = GitPatch(unidiff_patch=PATCH, base_commit_id="abc", suggested_commit_message="")
+        assert sum(h.added for h in patch.hunks()) == 5
@@ -0,0 +1,84 @@
+"""Run the feature examples against fake clients so they keep working."""
+
+import importlib.util
+import os
+from unittest.mock import Mock
+
+from jules_agent_sdk import JulesAPIError, PlanSupersededError
+from jules_agent_sdk.models import Activity, Session, SessionState
+
+EXAMPLES = os.path.join(os.path.dirname(__file__), "..", "examples")
+
+
+def load_example(name):
+    """Import an example script as a module."""
+    spec = importlib.util.spec_from_file_location(name, os.path.join(EXAMPLES, f"{name}.py"))
+    module = importlib.util.module_from_spec(spec)
+    spec.loader.exec_module(module)
+    return module
+
+
+def session(name, state):
+    """Build a session in a state."""
+    return Session.from_dict({"name": name, "prompt": "p", "sourceContext": {}, "state": state})
+
+
+class TestExamples:
+    """Test cases for the examples."""
+
+    def test_create_and_wait(self):
+        """Test create_and_wait creates a session and waits on it."""
+        client = Mock()
+        client.sessions.create.return_value = session("sessions/1", SessionState.QUEUED)
+        client.sessions.wait_for_completion.return_value = session(
+            "sessions/1", SessionState.COMPLETED
+        )
+
+        final = load_example("create_and_wait").run(client, "octo/app", "Add type hints")
+
+        assert final.state == SessionState.COMPLETED
+        assert client.sessions.wait_for_completion.call_args[0] == ("sessions/1",)
+
+    def test_approval_bot(self):
+        """Test approval_bot approves small plans and pushes back on large ones."""
+        bot = load_example("approval_bot")
+        client = Mock()
+        client.sessions.get.return_value = session(
+            "sessions/1", SessionState.AWAITING_PLAN_APPROVAL
+        )
+        steps = [{"id": str(i), "title": f"Step {i}"} for i in range(3)]
+        plan = {"id": "p1", "steps": steps}
+        client.activities.list_all.return_value = [
+            Activity.from_dict({"name": "a1", "planGenerated": {"plan": plan}})
+        ]
+
+        assert bot.review(client, "sessions/1", max_steps=5) == "approved"
+        client.sessions.approve_plan.assert_called_once_with("sessions/1", plan_id="p1")
+
+        assert bot.review(client, "sessions/1", max_steps=2) == "rejected"
+        client.sessions.send_message.assert_called_once()
+
+        client.sessions.approve_plan.side_effect = PlanSupersededError("sessions/1", "p1", None)
+        assert bot.review(client, "sessions/1") == "superseded"
+
+        client.sessions.get.return_value = session("sessions/1", SessionState.IN_PROGRESS)
+        assert bot.review(client, "sessions/1") == "idle"
+
+    def test_fan_out(self):
+        """Test fan_out reports each source and skips failed ones."""
+        client = Mock()
+        client.sessions.create.side_effect = lambda prompt, source: session(
+            f"sessions/{source}", SessionState.QUEUED
+        )
+
+        def wait(name, **kwargs):
+            if name == "sessions/bad":
+                raise JulesAPIError(f"Session failed: {name}")
+            return session(name, SessionState.COMPLETED)
+
+        client.sessions.wait_for_completion.side_effect = wait
+
+        results = load_example("fan_out").run(client, "Upgrade", ["good", "bad", "also-good"])
+
+        assert sorted(results) == ["also-good", "good"]
+        assert all(s.state == SessionState.COMPLETED for s in results.values())
@@ -0,0 +1,49 @@
+"""Tests for exception classification helpers."""
+
+import pytest
+from requests.exceptions import ConnectionError
+from jules_agent_sdk import is_retryable, retry_delay_hint
+from jules_agent_sdk.exceptions import (
+    JulesAPIError,
+    JulesAuthenticationError,
+    JulesNotFoundError,
+    JulesRateLimitError,
+    JulesServerError,
+    JulesValidationError,
+    UnexpectedContentTypeError,
+)
+
+
+class TestRetryClassification:
+    """Test cases for is_retryable and retry_delay_hint."""
+
+    def test_transient_errors_are_retryable(self):
+        """Test server, rate limit and content-type 5xx errors are retryable."""
+        assert is_retryable(JulesServerError("boom", 503))
+        assert is_retryable(JulesRateLimitError("slow down", 429))
+        assert is_retryable(UnexpectedContentTypeError(502, "text/html", "<html/>"))
+
+    def test_client_errors_are_not_retryable(self):
+        """Test 4xx errors and HTML 200 pages are not retryable."""
+        assert not is_retryable(JulesAuthenticationError("bad key", 401))
+        assert not is_retryable(JulesNotFoundError("missing", 404))
+        assert not is_retryable(JulesValidationError("invalid", 400))
+        assert not is_retryable(UnexpectedContentTypeError(200, "text/html", "<html/>"))
+        assert not is_retryable(ValueError("not an API error"))
+
+    def test_wrapped_network_errors_are_retryable(self):
+        """Test API errors caused by network failures are retryable."""
+        try:
+            try:
+                raise ConnectionError("connection reset")
+            except ConnectionError as e:
+                raise JulesAPIError("Request failed after 3 attempts") from e
+        except JulesAPIError as err:
+            assert is_retryable(err)
+
+    def test_retry_delay_hint(self):
+        """Test delay hints come from rate limit errors only."""
+        limited = JulesRateLimitError("slow down", 429, {"retry_after_seconds": 7})
+        assert retry_delay_hint(limited) == 7.0
+        assert retry_delay_hint(JulesRateLimitError("slow down", 429, {})) is None
+        assert retry_delay_hint(JulesServerError("boom", 500)) is None
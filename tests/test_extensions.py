@@ -0,0 +1,58 @@
+"""Tests for the activity kind registry."""
+
+import pytest
+
+from jules_agent_sdk.decoding import DecodeError, DecodeOptions, decode
+from jules_agent_sdk.extensions import register_activity_kind, unregister_activity_kind
+from jules_agent_sdk.models import Activity
+
+TOOL_ACTIVITY = {
+    "name": "sessions/1/activities/2",
+    "toolInvoked": {"toolName": "grep", "arguments": ["-r", "TODO"]},
+}
+
+
+class TestActivityKindRegistry:
+    """Test cases for register_activity_kind."""
+
+    def teardown_method(self, method):
+        """Remove kinds registered by a test."""
+        unregister_activity_kind("toolInvoked")
+
+    def test_unknown_kinds_are_kept_raw(self):
+        """Test unknown event payloads survive decoding without a decoder."""
+        activity = Activity.from_dict(TOOL_ACTIVITY)
+
+        assert activity.kind == ""
+        assert activity.event("toolInvoked") == TOOL_ACTIVITY["toolInvoked"]
+        assert activity.event("somethingElse") is None
+
+    def test_registered_decoder(self):
+        """Test registered kinds are decoded, reported and accepted by strict decoding."""
+        options = DecodeOptions(reject_unknown_fields=True)
+        with pytest.raises(DecodeError):
+            decode(Activity, TOOL_ACTIVITY, options)
+
+        register_activity_kind("toolInvoked", lambda data: data["toolName"])
+        activity = decode(Activity, TOOL_ACTIVITY, options)
+
+        assert activity.kind == "toolInvoked"
+        assert activity.event("toolInvoked") == "grep"
+
+    def test_registration_conflicts(self):
+        """Test built-in and duplicate kinds are rejected unless replacing."""
+        with pytest.raises(ValueError, match="built-in"):
+            register_activity_kind("agentMessaged", dict)
+
+        register_activity_kind("toolInvoked", dict)
+        with pytest.raises(ValueError, match="already registered"):
+            register_activity_kind("toolInvoked", dict)
+        register_activity_kind("toolInvoked", str, replace=True)
+
+        assert Activity.from_dict(TOOL_ACTIVITY).event("toolInvoked").startswith("{")
+
+    def test_builtin_events(self):
+        """Test event() also returns built-in event payloads."""
+        activity = Activity.from_dict({"name": "a", "agentMessaged": {"agentMessage": "hi"}})
+        assert activity.event("agentMessaged") == {"agentMessage": "hi"}
+        assert activity.extensions == {}
@@ -0,0 +1,61 @@
+"""Tests for typed source filters."""
+
+import pytest
+
+from jules_agent_sdk.filters import SourceFilter, quote_filter_value, split_filter
+from jules_agent_sdk.models import Source
+
+
+def source(name, owner="octo", private=False):
+    """Build a GitHub source."""
+    return Source.from_dict(
+        {"name": name, "githubRepo": {"owner": owner, "repo": "r", "isPrivate": private}}
+    )
+
+
+class TestSourceFilter:
+    """Test cases for SourceFilter."""
+
+    def test_quote_filter_value(self):
+        """Test values are left bare when the grammar allows and quoted otherwise."""
+        assert quote_filter_value("sources/github/octo/app-1.0") == "sources/github/octo/app-1.0"
+        assert quote_filter_value("my repo") == '"my repo"'
+        assert quote_filter_value('a"b\\c') == '"a\\"b\\\\c"'
+        assert quote_filter_value("x OR name=y") == '"x OR name=y"'
+
+    def test_named_expression(self):
+        """Test names, IDs and shorthands become name comparisons joined by OR."""
+        f = SourceFilter.named("sources/github/octo/app", "github/octo/api", "octo/web")
+        assert f.expression() == (
+            "name=sources/github/octo/app OR name=sources/github/octo/api"
+            " OR name=sources/github/octo/web"
+        )
+        assert str(f) == f.expression()
+        assert SourceFilter.owned_by("octo").expression() is None
+
+        with pytest.raises(ValueError):
+            SourceFilter.named()
+
+    def test_combine_and_match(self):
+        """Test combined filters apply every condition."""
+        f = SourceFilter.owned_by("Octo") & SourceFilter.private()
+
+        assert f.matches(source("sources/github/octo/a", private=True))
+        assert not f.matches(source("sources/github/octo/b"))
+        assert not f.matches(source("sources/github/other/c", owner="other", private=True))
+        assert not f.matches(Source.from_dict({"name": "sources/other"}))
+
+        narrowed = SourceFilter.named("octo/a", "octo/b") & SourceFilter.named("octo/b")
+        assert narrowed.names == ("sources/github/octo/b",)
+
+        with pytest.raises(ValueError):
+            SourceFilter.named("octo/a") & SourceFilter.named("octo/b")
+        with pytest.raises(ValueError):
+            SourceFilter.private() & SourceFilter.private(False)
+
+    def test_split_filter(self):
+        """Test raw strings pass through and SourceFilters split in two."""
+        assert split_filter("name=sources/x") == ("name=sources/x", None)
+        assert split_filter(None) == (None, None)
+        f = SourceFilter.named("octo/a")
+        assert split_filter(f) == ("name=sources/github/octo/a", f)
@@ -0,0 +1,38 @@
+"""Tests for request log sampling."""
+
+import logging
+import pytest
+from jules_agent_sdk import SampledRequestFilter
+
+
+def make_record(level=logging.DEBUG, **extra):
+    """Build a log record carrying the given extra fields."""
+    record = logging.LogRecord("jules_agent_sdk.base", level, __file__, 1, "msg", None, None)
+    record.__dict__.update(extra)
+    return record
+
+
+class TestSampledRequestFilter:
+    """Test cases for SampledRequestFilter."""
+
+    def test_samples_successful_gets(self):
+        """Test successful GET records pass only when sampled."""
+        rolls = iter([0.05, 0.5])
+        log_filter = SampledRequestFilter(sample_rate=0.1, rand=lambda: next(rolls))
+
+        assert log_filter.filter(make_record(method="GET", status=200))
+        assert not log_filter.filter(make_record(method="GET", status=200))
+
+    def test_keeps_failures_and_other_records(self):
+        """Test errors, failed responses and non-GET records always pass."""
+        log_filter = SampledRequestFilter(sample_rate=0.0)
+
+        assert log_filter.filter(make_record(logging.WARNING, method="GET"))
+        assert log_filter.filter(make_record(method="GET", status=503))
+        assert log_filter.filter(make_record(method="POST", status=200))
+        assert log_filter.filter(make_record())
+
+    def test_invalid_rate(self):
+        """Test sample rates outside 0..1 are rejected."""
+        with pytest.raises(ValueError):
+            SampledRequestFilter(sample_rate=1.5)
@@ -0,0 +1,87 @@
+"""Tests for the per-session message queue."""
+
+import asyncio
+import threading
+import pytest
+from unittest.mock import patch
+from jules_agent_sdk import JulesClient
+from jules_agent_sdk.exceptions import JulesAPIError, JulesAuthenticationError
+from jules_agent_sdk.messaging import AsyncMessageQueue, MessageQueue
+
+
+class TestMessageQueue:
+    """Test cases for MessageQueue."""
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_sends_in_order_retrying_conflicts(self, mock_request):
+        """Test messages keep their order and 409s are retried with backoff."""
+        responses = iter([JulesAPIError("busy", 409), {}, {}, {}])
+
+        def respond(*args, **kwargs):
+            response = next(responses)
+            if isinstance(response, Exception):
+                raise response
+            return response
+
+        mock_request.side_effect = respond
+        delays = []
+        client = JulesClient(api_key="test-api-key")
+
+        with MessageQueue(client.sessions, "s1", sleep=delays.append) as queue:
+            for part in ("one", "two", "three"):
+                queue.put(part)
+
+        sent = [c.kwargs["json"]["prompt"] for c in mock_request.call_args_list]
+        assert sent == ["one", "one", "two", "three"]
+        assert delays == [2.0]
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_failure_stops_the_queue(self, mock_request):
+        """Test a permanent failure drops later messages and is raised."""
+        mock_request.side_effect = JulesAuthenticationError("bad key", 401)
+        client = JulesClient(api_key="test-api-key")
+
+        queue = MessageQueue(client.sessions, "s1", sleep=lambda s: None)
+        queue.put("one")
+        with pytest.raises(JulesAuthenticationError):
+            queue.flush(timeout=5)
+        with pytest.raises(JulesAuthenticationError):
+            queue.put("two")
+        assert mock_request.call_count == 1
+
+    def test_unexpected_error_stops_the_queue(self):
+        """Test a non-API error is raised by flush() instead of hanging it."""
+
+        queued = threading.Event()
+
+        class BrokenSender:
+            def send_message(self, session_id, prompt):
+                # Fail only once both messages are queued, so put() never sees the error
+                queued.wait(5)
+                raise RuntimeError("sender bug")
+
+        queue = MessageQueue(BrokenSender(), "s1")
+        queue.put("one")
+        queue.put("two")
+        queued.set()
+        with pytest.raises(RuntimeError, match="sender bug"):
+            queue.flush(timeout=5)
+        with pytest.raises(RuntimeError, match="sender bug"):
+            queue.close()
+
+    @pytest.mark.asyncio
+    async def test_async_unexpected_error_stops_the_queue(self):
+        """Test the async worker survives a non-API error and flush() returns."""
+        sent = []
+
+        class BrokenSender:
+            async def send_message(self, session_id, prompt):
+                sent.append(prompt)
+                raise RuntimeError("sender bug")
+
+        queue = AsyncMessageQueue(BrokenSender(), "s1")
+        await queue.put("one")
+        await queue.put("two")
+        with pytest.raises(RuntimeError, match="sender bug"):
+            await asyncio.wait_for(queue.flush(), timeout=5)
+        assert sent == ["one"]
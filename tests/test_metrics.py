@@ -0,0 +1,116 @@
+"""Tests for the request metrics middleware."""
+
+import sys
+import types
+from unittest.mock import patch
+
+import pytest
+import requests
+
+from jules_agent_sdk.client import JulesClient
+from jules_agent_sdk.metrics import Histogram, Metrics, route, status_class
+from tests.helpers import response
+
+
+class FakeFamily:
+    """Stand-in for prometheus_client metric families."""
+
+    def __init__(self, name, documentation, labels=None):
+        self.name = name
+        self.samples = []
+
+    def add_metric(self, labels, *values):
+        self.samples.append((labels, *values))
+
+
+def fake_prometheus_client():
+    """Build stand-ins for the prometheus_client modules collect() imports."""
+    core = types.ModuleType("prometheus_client.core")
+    core.CounterMetricFamily = core.HistogramMetricFamily = FakeFamily
+    package = types.ModuleType("prometheus_client")
+    package.core = core
+    return {"prometheus_client": package, "prometheus_client.core": core}
+
+
+class TestMetrics:
+    """Test cases for Metrics."""
+
+    def test_route(self):
+        """Test IDs are templated, keeping custom methods and source IDs whole."""
+        assert route("/v1alpha/sessions") == "/v1alpha/sessions"
+        assert route("/v1alpha/sessions/123") == "/v1alpha/sessions/{id}"
+        assert route("/v1alpha/sessions/123:sendMessage") == "/v1alpha/sessions/{id}:sendMessage"
+        assert (
+            route("/v1alpha/sessions/1/activities/a1") == "/v1alpha/sessions/{id}/activities/{id}"
+        )
+        assert route("/v1alpha/sources/github/octo/app") == "/v1alpha/sources/{id}"
+
+    def test_status_class(self):
+        """Test status codes map to classes and missing ones to transport."""
+        assert status_class(204) == "2xx"
+        assert status_class(503) == "5xx"
+        assert status_class(None) == "transport"
+
+    def test_histogram(self):
+        """Test observations land in the right bucket and export cumulatively."""
+        histogram = Histogram((0.1, 1.0))
+        for value in (0.05, 0.1, 0.5, 3.0):
+            histogram.observe(value)
+
+        assert histogram.cumulative() == [("0.1", 2), ("1.0", 3), ("+Inf", 4)]
+        assert histogram.count == 4
+        assert histogram.total == pytest.approx(3.65)
+
+    @patch("jules_agent_sdk.base.time.sleep")
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_records_attempts(self, mock_request, mock_sleep):
+        """Test every attempt is counted by status class, with retries and latency."""
+        mock_request.side_effect = [
+            requests.ConnectionError("connection reset"),
+            response(503, {"error": {"message": "unavailable"}}),
+            response(200, {"name": "sessions/s1", "sourceContext": {}}),
+            response(404, {"error": {"message": "not found"}}),
+        ]
+        metrics = Metrics()
+        client = JulesClient(api_key="test-key", middlewares=[metrics.middleware])
+
+        client.sessions.get("s1")
+        with pytest.raises(Exception):
+            client.sessions.get("s2")
+
+        route_key = ("GET", "/v1alpha/sessions/{id}")
+        assert metrics.requests == {
+            (*route_key, "transport"): 1,
+            (*route_key, "5xx"): 1,
+            (*route_key, "2xx"): 1,
+            (*route_key, "4xx"): 1,
+        }
+        assert metrics.retries == {route_key: 2}
+        assert metrics.latency[route_key].count == 4
+        assert metrics.errors() == {"transport": 1, "5xx": 1, "4xx": 1}
+
+    def test_collect(self):
+        """Test collect() yields Prometheus families with labelled samples."""
+        metrics = Metrics(buckets=(1.0,))
+        metrics.observe("GET", "/v1alpha/sessions/1", 200, 0.5)
+        metrics.observe("GET", "/v1alpha/sessions/2", 500, 2.0, attempt=2)
+
+        with patch.dict(sys.modules, fake_prometheus_client()):
+            families = {f.name: f for f in metrics.collect()}
+
+        labels = ["GET", "/v1alpha/sessions/{id}"]
+        assert families["jules_client_requests"].samples == [
+            (labels + ["2xx"], 1),
+            (labels + ["5xx"], 1),
+        ]
+        assert families["jules_client_errors"].samples == [(labels + ["5xx"], 1)]
+        assert families["jules_client_retries"].samples == [(labels, 1)]
+        assert families["jules_client_request_duration_seconds"].samples == [
+            (labels, [("1.0", 1), ("+Inf", 2)], 2.5)
+        ]
+
+    def test_missing_dependency(self):
+        """Test a helpful ImportError without prometheus-client installed."""
+        with patch.dict(sys.modules, {"prometheus_client": None}):
+            with pytest.raises(ImportError, match="jules-agent-sdk\\[metrics\\]"):
+                list(Metrics().collect())
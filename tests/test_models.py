@@ -9,6 +9,9 @@ from jules_agent_sdk.models import (
     Activity,
     SourceContext,
     GitHubRepoContext,
+    Plan,
+    PlanProgress,
+    Artifact,
 )
 
 
@@ -54,6 +57,31 @@ class TestModels:
         assert data["sourceContext"]["githubRepoContext"]["startingBranch"] == "main"
         assert data["title"] == "Bug Fix"
 
+    def test_to_dict_never_sends_empty_timestamps(self):
+        """Test unset timestamps are omitted and set ones survive a round trip."""
+
+        def time_keys(value):
+            if isinstance(value, dict):
+                found = {k: v for k, v in value.items() if k.endswith("Time")}
+                for v in value.values():
+                    found.update(time_keys(v))
+                return found
+            if isinstance(value, list):
+                found = {}
+                for v in value:
+                    found.update(time_keys(v))
+                return found
+            return {}
+
+        session = Session(prompt="Fix bug", source_context=SourceContext(source="sources/r"))
+        plan = Plan(id="p1", steps=[])
+        activity = Activity(name="sessions/1/activities/1")
+        for model in (session, plan, activity):
+            assert time_keys(model.to_dict()) == {}
+
+        stamped = Plan.from_dict({"id": "p1", "createTime": "2024-01-01T00:00:00Z"})
+        assert Plan.from_dict(stamped.to_dict()).create_time == "2024-01-01T00:00:00Z"
+
     def test_source_from_dict(self):
         """Test Source.from_dict() parsing."""
         data = {
@@ -112,3 +140,109 @@ class TestModels:
         assert serialized["owner"] == original_data["owner"]
         assert serialized["repo"] == original_data["repo"]
         assert serialized["isPrivate"] == original_data["isPrivate"]
+
+    def test_string_representations(self):
+        """Test __str__ output for logging."""
+        session = Session.from_dict(
+            {"name": "sessions/s1", "state": "IN_PROGRESS", "title": "Fix", "sourceContext": {}}
+        )
+        activity = Activity.from_dict(
+            {
+                "name": "sessions/s1/activities/a1",
+                "description": "Asked a question",
+                "agentMessaged": {"agentMessage": "Which branch?"},
+            }
+        )
+        plan = Plan.from_dict({"id": "p1", "steps": [{"id": "1"}, {"id": "2"}]})
+
+        assert str(SessionState.IN_PROGRESS) == "IN_PROGRESS"
+        assert f"{SessionState.COMPLETED}" == "COMPLETED"
+        assert str(session) == "Session(sessions/s1, IN_PROGRESS, title='Fix')"
+        assert activity.kind == "agentMessaged"
+        assert str(activity) == (
+            "Activity(sessions/s1/activities/a1, agentMessaged, 'Asked a question')"
+        )
+        assert str(plan) == "Plan(p1, 2 steps)"
+
+    @pytest.mark.parametrize(
+        "github_repo",
+        [
+            {
+                "owner": "octo",
+                "repo": "app",
+                "isPrivate": True,
+                "defaultBranch": {"displayName": "main"},
+                "branches": [{"displayName": "main"}, {"displayName": "dev"}],
+            },
+            {
+                "owner": "octo",
+                "repo": "app",
+                "isPrivate": True,
+                "defaultBranch": "main",
+                "branches": ["main", "dev"],
+            },
+        ],
+    )
+    def test_github_repo_branch_shapes(self, github_repo):
+        """Test object and string branch representations normalize the same way."""
+        source = Source.from_dict(
+            {"name": "sources/github/octo/app", "id": "github/octo/app", "githubRepo": github_repo}
+        )
+
+        repo = source.github_repo
+        assert repo.default_branch.display_name == "main"
+        assert [b.display_name for b in repo.branches] == ["main", "dev"]
+        assert repo.to_dict()["defaultBranch"] == {"displayName": "main"}
+
+    def test_safe_accessors(self):
+        """Test convenience accessors return empty values when nested fields are missing."""
+        session = Session.from_dict({"name": "sessions/1"})
+        assert session.starting_branch == ""
+        assert session.pull_request is None
+        assert Source.from_dict({"name": "sources/x", "id": "x"}).default_branch_name == ""
+
+        activity = Activity.from_dict({"name": "a1"})
+        assert activity.agent_message == ""
+        assert activity.user_message == ""
+        assert activity.failure_reason == ""
+        assert Artifact().git_patch is None
+
+        session = Session.from_dict(
+            {
+                "sourceContext": {"source": "s", "githubRepoContext": {"startingBranch": "dev"}},
+                "outputs": [{}, {"pullRequest": {"url": "https://github.com/o/r/pull/1"}}],
+            }
+        )
+        assert session.starting_branch == "dev"
+        assert session.pull_request.url == "https://github.com/o/r/pull/1"
+
+        activity = Activity.from_dict({"name": "a2", "sessionFailed": {"reason": "boom"}})
+        assert activity.failure_reason == "boom"
+
+    def test_plan_progress(self):
+        """Test progress updates are correlated with the approved plan's steps."""
+
+        def plan(plan_id, titles):
+            steps = [{"id": f"s{i}", "title": t, "index": i} for i, t in enumerate(titles)]
+            return {"planGenerated": {"plan": {"id": plan_id, "steps": steps}}}
+
+        raw = [
+            plan("p1", ["Old plan"]),
+            plan("p2", ["Read code", "Fix bug", "Add tests"]),
+            {"planApproved": {"planId": "p2"}},
+            {"progressUpdated": {"title": "Fix bug", "description": "Patched parser"}},
+            {"progressUpdated": {"title": "Running linters"}},
+        ]
+        activities = [Activity.from_dict({"name": f"a{i}", **a}) for i, a in enumerate(raw)]
+
+        progress = PlanProgress.from_activities(activities)
+        assert progress.plan.id == "p2"
+        assert str(progress) == "2/3 steps"
+        assert progress.fraction == pytest.approx(2 / 3)
+        assert progress.latest.title == "Running linters"
+        assert progress.latest.step_index is None
+
+        update = activities[3].progress.correlate(progress.plan)
+        assert update.step_index == 1
+        assert update.description == "Patched parser"
+        assert PlanProgress.from_activities(activities[2:]) is None
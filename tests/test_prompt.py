@@ -0,0 +1,88 @@
+"""Tests for prompt size and processing helpers."""
+
+import pytest
+from unittest.mock import patch
+from jules_agent_sdk import JulesClient, PromptTooLargeError
+from jules_agent_sdk.prompt import (
+    CHARS_PER_TOKEN,
+    estimate_tokens,
+    check_prompt,
+    truncate_prompt,
+    chunk_prompt,
+    normalize_whitespace,
+    scrub_emails,
+)
+
+
+class TestPrompt:
+    """Test cases for prompt helpers."""
+
+    def test_estimate_tokens(self):
+        """Test token estimate rounds up."""
+        assert estimate_tokens("") == 0
+        assert estimate_tokens("a" * CHARS_PER_TOKEN) == 1
+        assert estimate_tokens("a" * (CHARS_PER_TOKEN + 1)) == 2
+
+    def test_check_prompt(self):
+        """Test check_prompt raises a typed error with guidance."""
+        check_prompt("short", max_tokens=10)
+
+        with pytest.raises(PromptTooLargeError, match="chunk_prompt") as exc_info:
+            check_prompt("x" * 400, max_tokens=10)
+
+        assert exc_info.value.estimated_tokens == 100
+        assert exc_info.value.max_tokens == 10
+
+    def test_truncate_prompt_keeps_head_and_tail(self):
+        """Test truncation keeps the start and end of the prompt."""
+        text = "TASK " + "x" * 1000 + " ERROR"
+        truncated = truncate_prompt(text, max_tokens=50)
+
+        assert len(truncated) <= 50 * CHARS_PER_TOKEN
+        assert truncated.startswith("TASK")
+        assert truncated.endswith("ERROR")
+        assert truncate_prompt("fits", max_tokens=50) == "fits"
+
+    def test_chunk_prompt(self):
+        """Test chunking prefers paragraph boundaries and preserves content."""
+        paragraphs = ["a" * 30 + "\n\n", "b" * 30 + "\n\n", "c" * 30]
+        text = "".join(paragraphs)
+        chunks = chunk_prompt(text, max_tokens=10)
+
+        assert "".join(chunks) == text
+        assert chunks == paragraphs
+        assert all(estimate_tokens(c) <= 16 for c in chunk_prompt("z" * 500, max_tokens=16))
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_client_rejects_large_prompt(self, mock_request):
+        """Test the client checks prompt size before sending."""
+        client = JulesClient(api_key="test-api-key", max_prompt_tokens=10)
+
+        with pytest.raises(PromptTooLargeError):
+            client.sessions.create(prompt="x" * 100, source="sources/repo1")
+        with pytest.raises(PromptTooLargeError):
+            client.sessions.send_message("s1", "x" * 100)
+
+        mock_request.assert_not_called()
+
+    def test_builtin_processors(self):
+        """Test the bundled processors normalize whitespace and scrub emails."""
+        assert normalize_whitespace("  Fix it.  \n\n\n\nThanks \n") == "Fix it.\n\nThanks"
+        assert scrub_emails("From a.b+c@mail.example.com: crash") == "From [email]: crash"
+
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_client_applies_prompt_processors(self, mock_request):
+        """Test processors run in order on create prompts and messages."""
+        mock_request.return_value = {"name": "sessions/1", "sourceContext": {}}
+        client = JulesClient(
+            api_key="test-api-key",
+            prompt_processors=[scrub_emails, str.upper],
+            max_prompt_tokens=4,
+        )
+        client.sessions.add_prompt_processor(lambda text: text + "!")
+
+        client.sessions.create(prompt="ann@x.io", source="sources/repo1")
+        assert mock_request.call_args.kwargs["json"]["prompt"] == "[EMAIL]!"
+
+        client.sessions.send_message("s1", "hi bob@x.io")
+        assert mock_request.call_args.kwargs["json"] == {"prompt": "HI [EMAIL]!"}
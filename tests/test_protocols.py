@@ -0,0 +1,42 @@
+"""Tests for the single-operation protocols."""
+
+from unittest.mock import Mock
+
+from jules_agent_sdk.client import JulesClient
+from jules_agent_sdk.messaging import MessageQueue
+from jules_agent_sdk.protocols import ActivityLister, MessageSender, PlanApprover, SessionGetter
+
+
+class FakeSender:
+    """A MessageSender that records messages instead of sending them."""
+
+    def __init__(self):
+        self.sent = []
+
+    def send_message(self, session_id, prompt):
+        self.sent.append((session_id, prompt))
+
+
+class TestProtocols:
+    """Test cases for the protocols."""
+
+    def test_api_objects_satisfy_protocols(self):
+        """Test the client's API objects implement the narrow protocols."""
+        client = JulesClient(api_key="test-key")
+
+        assert isinstance(client.sessions, SessionGetter)
+        assert isinstance(client.sessions, PlanApprover)
+        assert isinstance(client.sessions, MessageSender)
+        assert isinstance(client.activities, ActivityLister)
+        assert not isinstance(Mock(spec=[]), MessageSender)
+
+    def test_message_queue_accepts_fake(self):
+        """Test a helper works with a fake implementing one method."""
+        sender = FakeSender()
+        assert isinstance(sender, MessageSender)
+
+        with MessageQueue(sender, "sessions/s1") as queue:
+            queue.put("first")
+            queue.put("second")
+
+        assert sender.sent == [("sessions/s1", "first"), ("sessions/s1", "second")]
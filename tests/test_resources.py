@@ -0,0 +1,77 @@
+"""Tests for URL and resource name helpers."""
+
+import pytest
+from jules_agent_sdk.exceptions import InvalidResourceNameError, JulesValidationError
+from jules_agent_sdk.resources import (
+    activity_path,
+    parse_resource_name,
+    parse_session_url,
+    session_path,
+    source_path,
+)
+
+
+class TestParseSessionURL:
+    """Test cases for parse_session_url."""
+
+    def test_extracts_id(self):
+        """Test session IDs are pulled out of pasted links."""
+        assert parse_session_url("https://jules.google.com/session/123456") == "123456"
+        assert parse_session_url(" https://jules.google.com/session/42/plan?x=1#top\n") == "42"
+
+    def test_rejects_other_urls(self):
+        """Test non-session and foreign URLs are rejected."""
+        for url in (
+            "https://example.com/session/123",
+            "https://jules.google.com/",
+            "jules.google.com/session/123",
+        ):
+            with pytest.raises(JulesValidationError):
+                parse_session_url(url)
+
+
+class TestParseResourceName:
+    """Test cases for parse_resource_name."""
+
+    def test_splits_collection(self):
+        """Test names are split on the first slash only."""
+        assert parse_resource_name("sessions/abc") == ("sessions", "abc")
+        assert parse_resource_name("sources/github/o/r") == ("sources", "github/o/r")
+
+    def test_rejects_bare_ids(self):
+        """Test values without a collection are rejected."""
+        for name in ("abc", "sessions/", "/abc"):
+            with pytest.raises(JulesValidationError):
+                parse_resource_name(name)
+
+
+class TestResourcePaths:
+    """Test cases for path builders used by the API classes."""
+
+    def test_session_path_lenient(self):
+        """Test IDs, names and URLs normalize to the same path."""
+        for value in ("abc", "sessions/abc", " abc\n", "https://jules.google.com/session/abc"):
+            assert session_path(value) == "sessions/abc"
+
+    def test_session_path_rejects_malformed(self):
+        """Test doubled prefixes, nested paths and method suffixes are rejected."""
+        for value in ("", "sessions/sessions/abc", "abc/activities/x", "abc:approvePlan"):
+            with pytest.raises(InvalidResourceNameError) as exc_info:
+                session_path(value)
+            assert exc_info.value.value == value
+
+    def test_session_path_strict(self):
+        """Test strict mode rejects URLs and whitespace."""
+        assert session_path("sessions/abc", strict=True) == "sessions/abc"
+        for value in ("https://jules.google.com/session/abc", " abc"):
+            with pytest.raises(InvalidResourceNameError):
+                session_path(value, strict=True)
+
+    def test_activity_and_source_paths(self):
+        """Test activity and multi-segment source paths."""
+        assert activity_path("abc", "act1") == "sessions/abc/activities/act1"
+        assert source_path("github/o/r") == "sources/github/o/r"
+        assert source_path("sources/github/o/r") == "sources/github/o/r"
+        for value in ("sources/sources/github/o/r", "github//r"):
+            with pytest.raises(InvalidResourceNameError):
+                source_path(value)
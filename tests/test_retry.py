@@ -0,0 +1,79 @@
+"""Tests for retry policies."""
+
+from unittest.mock import patch
+
+import pytest
+import requests
+
+from jules_agent_sdk.client import JulesClient
+from jules_agent_sdk.exceptions import JulesAPIError, JulesRateLimitError, JulesServerError
+from jules_agent_sdk.retry import (
+    TRANSPORT_DNS,
+    DecorrelatedJitter,
+    ExponentialBackoff,
+    FixedDelay,
+    NoRetry,
+)
+from tests.helpers import response
+
+
+class TestRetryPolicy:
+    """Test cases for retry policies."""
+
+    def test_should_retry(self):
+        """Test retryable errors, the attempt cap and methods excluded from retries."""
+        policy = FixedDelay(max_attempts=3, no_retry_methods=["post"])
+        server_error = JulesServerError("unavailable", 503)
+
+        assert policy.should_retry("GET", server_error, 1)
+        assert not policy.should_retry("GET", server_error, 3)
+        assert not policy.should_retry("POST", server_error, 1)
+        assert not policy.should_retry("GET", JulesAPIError("bad request", 400), 1)
+
+        timeout = requests.Timeout("read timed out")
+        assert policy.should_retry("GET", timeout, 1)
+        assert not FixedDelay(retry_transport_errors=[TRANSPORT_DNS]).should_retry(
+            "GET", timeout, 1
+        )
+
+    def test_delays(self):
+        """Test each policy's delays, and that Retry-After hints win."""
+        error = JulesServerError("unavailable", 503)
+        exponential = ExponentialBackoff(factor=1.0, max_backoff=5.0)
+        assert [exponential.next_delay(error, n, 0) for n in (1, 2, 3, 4)] == [1, 2, 4, 5]
+        assert FixedDelay(2.5).next_delay(error, 3, 2.5) == 2.5
+
+        jitter = DecorrelatedJitter(base=1.0, cap=10.0)
+        with patch("jules_agent_sdk.retry.random.uniform", side_effect=lambda lo, hi: hi):
+            assert jitter.next_delay(error, 1, 0) == 1.0
+            assert jitter.next_delay(error, 2, 2.0) == 6.0
+            assert jitter.next_delay(error, 3, 6.0) == 10.0
+
+        rate_limited = JulesRateLimitError("slow down", 429, {"retry_after_seconds": 7.0})
+        assert jitter.next_delay(rate_limited, 1, 0) == 7.0
+
+    @patch("jules_agent_sdk.base.time.sleep")
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_client_uses_policy(self, mock_request, mock_sleep):
+        """Test the client follows a custom policy's attempts and delays."""
+        mock_request.side_effect = [
+            response(503),
+            response(503),
+            response(200, {"name": "sessions/s1"}),
+        ]
+        client = JulesClient(api_key="test-key", max_retries=1, retry_policy=FixedDelay(0.5))
+
+        assert client.sessions.get("s1").name == "sessions/s1"
+        assert [c.args[0] for c in mock_sleep.call_args_list] == [0.5, 0.5]
+
+    @patch("jules_agent_sdk.base.time.sleep")
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_no_retry(self, mock_request, mock_sleep):
+        """Test NoRetry attempts each request once."""
+        mock_request.side_effect = [response(503)]
+        client = JulesClient(api_key="test-key", retry_policy=NoRetry())
+
+        with pytest.raises(JulesServerError):
+            client.sessions.get("s1")
+        assert mock_request.call_count == 1
+        mock_sleep.assert_not_called()
@@ -0,0 +1,55 @@
+"""Tests for the fake model builders."""
+
+import pytest
+
+from jules_agent_sdk.models import Activity, Session, SessionState
+from jules_agent_sdk.testing import fake_activity, fake_patch, fake_session
+
+
+class TestFakes:
+    """Test cases for fake_session, fake_activity and fake_patch."""
+
+    def test_fake_session(self):
+        """Test sessions are consistent and round-trip through from_dict."""
+        session = fake_session(source="sources/github/acme/shop", starting_branch="develop")
+
+        assert session.state == SessionState.COMPLETED
+        assert session.name == f"sessions/{session.id}"
+        assert session.starting_branch == "develop"
+        assert session.pull_request.url.startswith("https://github.com/acme/shop/pull/")
+        assert Session.from_dict({**session.to_dict(), "id": session.id}).title == session.title
+
+        running = fake_session("IN_PROGRESS", session_id="42", title="Custom")
+        assert running.name == "sessions/42"
+        assert running.title == "Custom"
+        assert running.pull_request is None
+        assert fake_session().id != fake_session().id
+
+    def test_fake_activity(self):
+        """Test activities carry the requested event and belong to the session."""
+        session = fake_session()
+        plan = fake_activity("planGenerated", session=session)
+
+        assert plan.kind == "planGenerated"
+        assert plan.name.startswith(f"{session.name}/activities/")
+        assert len(plan.plan.steps) == 3
+        assert fake_activity("userMessaged").originator == "user"
+
+        done = fake_activity("sessionCompleted", session=session, description="Done")
+        assert done.description == "Done"
+        assert done.artifacts[0].change_set.source == session.source_context.source
+        assert isinstance(Activity.from_dict(done.to_dict()), Activity)
+
+        with pytest.raises(ValueError):
+            fake_activity("toolInvoked")
+
+    def test_fake_patch(self):
+        """Test patches parse into one hunk per file with the requested lines."""
+        patch = fake_patch(["app.py", "tests/test_app.py"], lines=4)
+        hunks = list(patch.hunks())
+
+        assert [h.new_path for h in hunks] == ["app.py", "tests/test_app.py"]
+        assert [h.added for h in hunks] == [4, 4]
+        assert [h.new_count for h in hunks] == [5, 5]
+        assert len(list(fake_patch(3).hunks())) == 3
+        assert fake_patch(suggested_commit_message="Bump").suggested_commit_message == "Bump"
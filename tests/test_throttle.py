@@ -0,0 +1,44 @@
+"""Tests for the session creation throttle."""
+
+import pytest
+from unittest.mock import patch
+from jules_agent_sdk import JulesClient
+from jules_agent_sdk.throttle import CreateThrottle
+
+
+class TestCreateThrottle:
+    """Test cases for CreateThrottle."""
+
+    def test_sliding_window(self):
+        """Test slots are handed out N per 60 seconds."""
+        now = [0.0]
+        throttle = CreateThrottle(2, clock=lambda: now[0])
+
+        assert throttle.reserve() == 0
+        now[0] = 10.0
+        assert throttle.reserve() == 0
+        assert throttle.reserve() == 50.0
+        assert throttle.reserve() == 60.0
+
+        now[0] = 200.0
+        assert throttle.reserve() == 0
+
+    def test_rejects_non_positive_limit(self):
+        """Test the limit must be positive."""
+        with pytest.raises(ValueError):
+            CreateThrottle(0)
+
+    @patch("jules_agent_sdk.throttle.time.sleep")
+    @patch("jules_agent_sdk.base.BaseClient._request")
+    def test_create_waits_locally(self, mock_request, mock_sleep):
+        """Test creates over the limit sleep instead of failing."""
+        mock_request.return_value = {"name": "sessions/1", "id": "1"}
+        client = JulesClient(api_key="test-api-key", max_creates_per_minute=1)
+
+        client.sessions.create(prompt="a", source="sources/repo1")
+        mock_sleep.assert_not_called()
+        client.sessions.create(prompt="b", source="sources/repo1")
+
+        assert mock_request.call_count == 2
+        assert mock_sleep.call_count == 1
+        assert 59 < mock_sleep.call_args[0][0] <= 60
@@ -0,0 +1,98 @@
+"""Tests for the OpenTelemetry tracing middleware."""
+
+import sys
+import types
+from contextlib import contextmanager
+from unittest.mock import patch
+
+import pytest
+
+from jules_agent_sdk.client import JulesClient
+from tests.helpers import response
+
+
+class FakeSpan:
+    """Records attributes and status set on a span."""
+
+    def __init__(self, name):
+        self.name = name
+        self.attributes = {}
+        self.status = None
+
+    def set_attribute(self, key, value):
+        self.attributes[key] = value
+
+    def set_status(self, status):
+        self.status = status
+
+
+class FakeTracer:
+    """Collects the spans it starts."""
+
+    def __init__(self):
+        self.spans = []
+
+    @contextmanager
+    def start_as_current_span(self, name, kind=None):
+        span = FakeSpan(name)
+        self.spans.append(span)
+        yield span
+
+
+def fake_opentelemetry():
+    """Build stand-ins for the opentelemetry modules the middleware imports."""
+    trace = types.ModuleType("opentelemetry.trace")
+    trace.SpanKind = types.SimpleNamespace(CLIENT="client")
+    trace.StatusCode = types.SimpleNamespace(ERROR="error")
+    trace.Status = lambda code: code
+    trace.get_tracer = lambda name: FakeTracer()
+
+    propagate = types.ModuleType("opentelemetry.propagate")
+    propagate.inject = lambda carrier: carrier.update({"traceparent": "00-abc-def-01"})
+
+    package = types.ModuleType("opentelemetry")
+    package.trace, package.propagate = trace, propagate
+    return {
+        "opentelemetry": package,
+        "opentelemetry.trace": trace,
+        "opentelemetry.propagate": propagate,
+    }
+
+
+class TestTracingMiddleware:
+    """Test cases for tracing_middleware."""
+
+    @patch("jules_agent_sdk.base.time.sleep")
+    @patch("jules_agent_sdk.base.requests.Session.request")
+    def test_spans_per_attempt(self, mock_request, mock_sleep):
+        """Test each attempt gets a span with status, attempt and trace headers."""
+
+        mock_request.side_effect = [
+            response(503, {"error": {"message": "unavailable"}}),
+            response(200, {"name": "sessions/s1", "sourceContext": {}}),
+        ]
+
+        with patch.dict(sys.modules, fake_opentelemetry()):
+            from jules_agent_sdk.tracing import tracing_middleware
+
+            tracer = FakeTracer()
+            client = JulesClient(api_key="test-key", middlewares=[tracing_middleware(tracer)])
+            client.sessions.get("s1")
+
+        failed, succeeded = tracer.spans
+        assert failed.name == "GET /v1alpha/sessions/{id}"
+        assert failed.attributes["url.path"] == "/v1alpha/sessions/s1"
+        assert failed.attributes["http.response.status_code"] == 503
+        assert failed.attributes["http.request.resend_count"] == 0
+        assert failed.status == "error"
+        assert succeeded.attributes["http.request.resend_count"] == 1
+        assert succeeded.status is None
+        assert mock_request.call_args.kwargs["headers"]["traceparent"] == "00-abc-def-01"
+
+    def test_missing_dependency(self):
+        """Test a helpful ImportError without opentelemetry installed."""
+        from jules_agent_sdk.tracing import tracing_middleware
+
+        with patch.dict(sys.modules, {"opentelemetry": None}):
+            with pytest.raises(ImportError, match="jules-agent-sdk\\[tracing\\]"):
+                tracing_middleware()
@@ -0,0 +1,95 @@
+"""Tests for the debug wire dump."""
+
+import io
+from unittest.mock import patch
+
+import pytest
+import requests
+
+from jules_agent_sdk.client import JulesClient
+from jules_agent_sdk.exceptions import JulesValidationError
+from jules_agent_sdk.wiredump import redact_headers, redact_url
+
+
+def send(status, reason, body):
+    """Build a fake adapter send() returning a real response to the prepared request."""
+
+    def respond(request, **kwargs):
+        response = requests.Response()
+        response.status_code = status
+        response.reason = reason
+        response.headers["Content-Type"] = "application/json"
+        response._content = body
+        response.request = request
+        response.url = request.url
+        return response
+
+    return respond
+
+
+class TestWireDump:
+    """Test cases for the wire dump."""
+
+    def test_redaction(self):
+        """Test credential headers and query parameters are redacted."""
+        headers = {"X-Goog-Api-Key": "secret", "Authorization": "Bearer t", "Accept": "*/*"}
+        assert redact_headers(headers) == {
+            "X-Goog-Api-Key": "REDACTED",
+            "Authorization": "REDACTED",
+            "Accept": "*/*",
+        }
+        assert redact_url("https://h/v1/s?key=secret&pageSize=5") == (
+            "https://h/v1/s?key=REDACTED&pageSize=5"
+        )
+        assert redact_url("https://h/v1/s") == "https://h/v1/s"
+
+    @patch("jules_agent_sdk.base.TransportAdapter.send")
+    def test_dumps_request_and_response(self, mock_send):
+        """Test a rejected request is dumped in full with the API key redacted."""
+        mock_send.side_effect = send(400, "Bad Request", b'{"error": {"message": "bad prompt"}}')
+        dump = io.StringIO()
+        client = JulesClient(api_key="secret-key", wire_dump=dump)
+
+        with pytest.raises(JulesValidationError):
+            client.sessions.create(prompt="Fix bug", source="sources/repo1")
+
+        text = dump.getvalue()
+        assert ">>> POST https://jules.googleapis.com/v1alpha/sessions\n" in text
+        assert "X-Goog-Api-Key: REDACTED" in text
+        assert "secret-key" not in text
+        assert '"prompt": "Fix bug"' in text
+        assert "<<< 400 Bad Request (" in text
+        assert '{"error": {"message": "bad prompt"}}' in text
+
+    @patch("jules_agent_sdk.base.time.sleep")
+    @patch("jules_agent_sdk.base.TransportAdapter.send")
+    def test_dumps_transport_errors(self, mock_send, mock_sleep):
+        """Test attempts that fail without a response are dumped with the error."""
+        mock_send.side_effect = requests.ConnectionError("connection refused")
+        dump = io.StringIO()
+        client = JulesClient(api_key="secret-key", max_retries=1, wire_dump=dump)
+
+        with pytest.raises(Exception):
+            client.sessions.get("s1")
+
+        text = dump.getvalue()
+        assert ">>> GET https://jules.googleapis.com/v1alpha/sessions/s1\n" in text
+        assert "!!! ConnectionError: connection refused" in text
+
+    @patch("jules_agent_sdk.base.TransportAdapter.send")
+    def test_dump_is_innermost(self, mock_send):
+        """Test the dump shows headers added by middlewares."""
+        mock_send.side_effect = send(200, "OK", b'{"name": "sessions/s1"}')
+
+        def add_tenant(next_doer):
+            def doer(method, url, **kwargs):
+                kwargs["headers"] = {**(kwargs.get("headers") or {}), "X-Tenant": "core"}
+                return next_doer(method=method, url=url, **kwargs)
+
+            return doer
+
+        dump = io.StringIO()
+        client = JulesClient(api_key="secret-key", middlewares=[add_tenant], wire_dump=dump)
+        client.sessions.get("s1")
+
+        assert "X-Tenant: core" in dump.getvalue()